@@ -0,0 +1,229 @@
+package itertools
+
+import (
+	"cmp"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Aggregator describes a reduction of the elements of a group to a single
+// value, such as a count or a sum. Aggregate asks it for a new Accumulator
+// for every group.
+//
+// The built-in aggregators are AggFold, AggCount, AggSum, AggMin, AggMax,
+// AggMean, AggFirst and AggLast. Custom reductions can implement Aggregator
+// directly or be built with AggFold.
+type Aggregator[V any] interface {
+	NewAccumulator() Accumulator[V]
+}
+
+// Accumulator holds the running state of an Aggregator for one group.
+type Accumulator[V any] interface {
+	// Add folds one element into the state.
+	Add(v V)
+	// Result returns the value of the reduction so far.
+	Result() any
+}
+
+// AggregateResult holds the results of Aggregate for one group, by the name
+// of each aggregator.
+type AggregateResult[K comparable] struct {
+	Key    K
+	Values map[string]any
+}
+
+// Aggregate consumes the iterator, groups its elements by key and runs every
+// aggregator over each group, like a SQL GROUP BY. Only the accumulators are
+// kept, so memory grows with the number of groups rather than the number of
+// elements. The results are returned in the order in which their keys were
+// first seen.
+//
+// Example:
+//
+//	type Sale struct { Region string; Amount float64 }
+//	report := itertools.Aggregate(sales, func(s Sale) string { return s.Region },
+//	    map[string]itertools.Aggregator[Sale]{
+//	        "orders":  itertools.AggCount[Sale](),
+//	        "revenue": itertools.AggSum(func(s Sale) float64 { return s.Amount }),
+//	        "average": itertools.AggMean(func(s Sale) float64 { return s.Amount }),
+//	    })
+//	for _, r := range report {
+//	    fmt.Println(r.Key, r.Values["orders"], r.Values["revenue"], r.Values["average"])
+//	}
+func Aggregate[V any, K comparable](it *Iterator[V], key func(V) K, aggs map[string]Aggregator[V]) []AggregateResult[K] {
+	type group struct {
+		key  K
+		accs map[string]Accumulator[V]
+	}
+
+	var groups []*group
+	index := make(map[K]*group)
+	it.seq(func(v V) bool {
+		k := key(v)
+		g, ok := index[k]
+		if !ok {
+			g = &group{key: k, accs: make(map[string]Accumulator[V], len(aggs))}
+			for name, agg := range aggs {
+				g.accs[name] = agg.NewAccumulator()
+			}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		for _, acc := range g.accs {
+			acc.Add(v)
+		}
+		return true
+	})
+
+	results := make([]AggregateResult[K], len(groups))
+	for i, g := range groups {
+		values := make(map[string]any, len(g.accs))
+		for name, acc := range g.accs {
+			values[name] = acc.Result()
+		}
+		results[i] = AggregateResult[K]{Key: g.key, Values: values}
+	}
+	return results
+}
+
+// foldAggregator is an Aggregator defined by an initial state, a step
+// function and a function that turns the state into the result.
+type foldAggregator[V, S any] struct {
+	init   func() S
+	add    func(S, V) S
+	result func(S) any
+}
+
+func (a foldAggregator[V, S]) NewAccumulator() Accumulator[V] {
+	return &foldAccumulator[V, S]{agg: a, state: a.init()}
+}
+
+type foldAccumulator[V, S any] struct {
+	agg   foldAggregator[V, S]
+	state S
+}
+
+func (a *foldAccumulator[V, S]) Add(v V) {
+	a.state = a.agg.add(a.state, v)
+}
+
+func (a *foldAccumulator[V, S]) Result() any {
+	return a.agg.result(a.state)
+}
+
+// AggFold returns an Aggregator that reduces each group with transform,
+// like Fold, starting from the value returned by init. init is called once
+// per group, so groups never share a map or a slice. Its result has type T.
+//
+// Example:
+//
+//	customers := itertools.AggFold(func(seen map[string]bool, s Sale) map[string]bool {
+//	    seen[s.Customer] = true
+//	    return seen
+//	}, func() map[string]bool { return make(map[string]bool) })
+func AggFold[V, T any](transform func(T, V) T, init func() T) Aggregator[V] {
+	return foldAggregator[V, T]{
+		init:   init,
+		add:    transform,
+		result: func(acc T) any { return acc },
+	}
+}
+
+// AggCount returns an Aggregator that counts the elements of each group.
+// Its result has type int.
+func AggCount[V any]() Aggregator[V] {
+	return AggFold(func(n int, _ V) int { return n + 1 }, func() int { return 0 })
+}
+
+// AggSum returns an Aggregator that sums transform over each group, like Sum.
+// Its result has type T.
+func AggSum[V any, T cmp.Ordered](transform func(V) T) Aggregator[V] {
+	return AggFold(func(acc T, v V) T { return acc + transform(v) }, func() T {
+		var zero T
+		return zero
+	})
+}
+
+// optional is the state of AggMin, AggMax and AggFirst: a value and whether
+// one has been seen.
+type optional[T any] struct {
+	value T
+	found bool
+}
+
+// AggMin returns an Aggregator that finds the smallest value of transform in
+// each group. Its result has type T.
+func AggMin[V any, T cmp.Ordered](transform func(V) T) Aggregator[V] {
+	return foldAggregator[V, optional[T]]{
+		init: func() optional[T] { return optional[T]{} },
+		add: func(e optional[T], v V) optional[T] {
+			if t := transform(v); !e.found || t < e.value {
+				return optional[T]{value: t, found: true}
+			}
+			return e
+		},
+		result: func(e optional[T]) any { return e.value },
+	}
+}
+
+// AggMax returns an Aggregator that finds the largest value of transform in
+// each group. Its result has type T.
+func AggMax[V any, T cmp.Ordered](transform func(V) T) Aggregator[V] {
+	return foldAggregator[V, optional[T]]{
+		init: func() optional[T] { return optional[T]{} },
+		add: func(e optional[T], v V) optional[T] {
+			if t := transform(v); !e.found || t > e.value {
+				return optional[T]{value: t, found: true}
+			}
+			return e
+		},
+		result: func(e optional[T]) any { return e.value },
+	}
+}
+
+// mean is the state of AggMean.
+type mean struct {
+	sum   float64
+	count int
+}
+
+// AggMean returns an Aggregator that averages transform over each group.
+// Its result has type float64.
+func AggMean[V any, T constraints.Integer | constraints.Float](transform func(V) T) Aggregator[V] {
+	return foldAggregator[V, mean]{
+		init: func() mean { return mean{} },
+		add: func(m mean, v V) mean {
+			return mean{sum: m.sum + float64(transform(v)), count: m.count + 1}
+		},
+		result: func(m mean) any {
+			if m.count == 0 {
+				return 0.0
+			}
+			return m.sum / float64(m.count)
+		},
+	}
+}
+
+// AggFirst returns an Aggregator that keeps the value of transform for the
+// first element of each group. Its result has type T.
+func AggFirst[V, T any](transform func(V) T) Aggregator[V] {
+	return foldAggregator[V, optional[T]]{
+		init: func() optional[T] { return optional[T]{} },
+		add: func(e optional[T], v V) optional[T] {
+			if e.found {
+				return e
+			}
+			return optional[T]{value: transform(v), found: true}
+		},
+		result: func(e optional[T]) any { return e.value },
+	}
+}
+
+// AggLast returns an Aggregator that keeps the value of transform for the
+// last element of each group. Its result has type T.
+func AggLast[V, T any](transform func(V) T) Aggregator[V] {
+	return AggFold(func(_ T, v V) T { return transform(v) }, func() T {
+		var zero T
+		return zero
+	})
+}
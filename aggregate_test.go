@@ -0,0 +1,108 @@
+package itertools_test
+
+import (
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+type regionSale struct {
+	Region   string
+	Customer string
+	Amount   float64
+	Units    int
+}
+
+var regionSales = []regionSale{
+	{"EU", "acme", 100, 2},
+	{"US", "globex", 50, 1},
+	{"EU", "initech", 300, 5},
+	{"APAC", "acme", 20, 1},
+	{"US", "umbrella", 150, 3},
+}
+
+func TestAggregate(t *testing.T) {
+	amount := func(s regionSale) float64 { return s.Amount }
+	report := itertools.Aggregate(itertools.ToIter(regionSales), func(s regionSale) string { return s.Region },
+		map[string]itertools.Aggregator[regionSale]{
+			"orders":  itertools.AggCount[regionSale](),
+			"revenue": itertools.AggSum(amount),
+			"units":   itertools.AggSum(func(s regionSale) int { return s.Units }),
+			"min":     itertools.AggMin(amount),
+			"max":     itertools.AggMax(amount),
+			"average": itertools.AggMean(amount),
+			"first":   itertools.AggFirst(func(s regionSale) string { return s.Customer }),
+			"last":    itertools.AggLast(func(s regionSale) string { return s.Customer }),
+		})
+
+	assert.Equal(t, []itertools.AggregateResult[string]{
+		{Key: "EU", Values: map[string]any{
+			"orders": 2, "revenue": 400.0, "units": 7, "min": 100.0, "max": 300.0,
+			"average": 200.0, "first": "acme", "last": "initech",
+		}},
+		{Key: "US", Values: map[string]any{
+			"orders": 2, "revenue": 200.0, "units": 4, "min": 50.0, "max": 150.0,
+			"average": 100.0, "first": "globex", "last": "umbrella",
+		}},
+		{Key: "APAC", Values: map[string]any{
+			"orders": 1, "revenue": 20.0, "units": 1, "min": 20.0, "max": 20.0,
+			"average": 20.0, "first": "acme", "last": "acme",
+		}},
+	}, report)
+}
+
+func TestAggregate_Fold(t *testing.T) {
+	report := itertools.Aggregate(itertools.Range(1, 10), func(x int) bool { return x%2 == 0 },
+		map[string]itertools.Aggregator[int]{
+			"product": itertools.AggFold(func(acc, x int) int { return acc * x }, func() int { return 1 }),
+			"mean":    itertools.AggMean(func(x int) int { return x }),
+		})
+
+	assert.Equal(t, 2, len(report))
+	assert.Equal(t, false, report[0].Key)
+	assert.Equal(t, 945, report[0].Values["product"])
+	assert.Equal(t, 5.0, report[0].Values["mean"])
+	assert.Equal(t, 384, report[1].Values["product"])
+}
+
+func TestAggregate_FoldStateIsPerGroup(t *testing.T) {
+	report := itertools.Aggregate(itertools.Range(0, 4), func(x int) int { return x % 2 },
+		map[string]itertools.Aggregator[int]{
+			"seen": itertools.AggFold(func(seen map[int]bool, x int) map[int]bool {
+				seen[x] = true
+				return seen
+			}, func() map[int]bool { return make(map[int]bool) }),
+		})
+
+	assert.Equal(t, map[int]bool{0: true, 2: true}, report[0].Values["seen"])
+	assert.Equal(t, map[int]bool{1: true, 3: true}, report[1].Values["seen"])
+}
+
+// distinctCount is a custom Aggregator implemented directly.
+type distinctCount struct{}
+
+func (distinctCount) NewAccumulator() itertools.Accumulator[regionSale] {
+	return &distinctAcc{seen: make(map[string]struct{})}
+}
+
+type distinctAcc struct {
+	seen map[string]struct{}
+}
+
+func (a *distinctAcc) Add(s regionSale) { a.seen[s.Customer] = struct{}{} }
+func (a *distinctAcc) Result() any      { return len(a.seen) }
+
+func TestAggregate_CustomAggregator(t *testing.T) {
+	report := itertools.Aggregate(itertools.ToIter(regionSales), func(s regionSale) string { return "all" },
+		map[string]itertools.Aggregator[regionSale]{"customers": distinctCount{}})
+
+	assert.Equal(t, 4, report[0].Values["customers"])
+}
+
+func TestAggregate_Empty(t *testing.T) {
+	report := itertools.Aggregate(itertools.ToIter([]regionSale{}), func(s regionSale) string { return s.Region },
+		map[string]itertools.Aggregator[regionSale]{"orders": itertools.AggCount[regionSale]()})
+
+	assert.Empty(t, report)
+}
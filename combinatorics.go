@@ -0,0 +1,338 @@
+package itertools
+
+// CombinatoricsOption configures Permutations, Combinations,
+// CombinationsWithReplacement, Powerset and CartesianProductN.
+type CombinatoricsOption func(*combinatoricsConfig)
+
+type combinatoricsConfig struct {
+	reuse bool
+}
+
+// WithReuseBuffer makes the combinatoric iterators yield the same slice each
+// time, overwritten in place, so that enumeration does not allocate. A
+// yielded slice is only valid until the next one is yielded; copy it to keep
+// it.
+//
+// Example:
+//
+//	for combo := range itertools.Combinations(it, 3, itertools.WithReuseBuffer()).Seq() {
+//	    run(combo) // must not retain combo
+//	}
+func WithReuseBuffer() CombinatoricsOption {
+	return func(c *combinatoricsConfig) {
+		c.reuse = true
+	}
+}
+
+// indexEmitter turns index tuples into slices of pool elements, reusing a
+// single buffer when configured to.
+type indexEmitter[V any] struct {
+	pool  []V
+	reuse bool
+	buf   []V
+}
+
+func newIndexEmitter[V any](pool []V, opts []CombinatoricsOption) *indexEmitter[V] {
+	var cfg combinatoricsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &indexEmitter[V]{pool: pool, reuse: cfg.reuse}
+}
+
+func (e *indexEmitter[V]) emit(indices []int, yield func([]V) bool) bool {
+	out := e.buf[:0]
+	if !e.reuse || out == nil {
+		out = make([]V, 0, len(indices))
+	}
+	for _, i := range indices {
+		out = append(out, e.pool[i])
+	}
+	if e.reuse {
+		e.buf = out
+	}
+	return yield(out)
+}
+
+// Permutations returns an iterator over all ordered arrangements of k
+// elements of it, in lexicographic order of position, like
+// Python's itertools.permutations. Elements are told apart by position, not
+// by value. Nothing is yielded if k is negative or larger than the number of
+// elements.
+//
+// Note: The input is collected into memory when iteration starts. The
+// permutations themselves are generated lazily.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"a", "b", "c"})
+//	perms := itertools.Permutations(iter, 2).Collect()
+//	// perms is [[a b] [a c] [b a] [b c] [c a] [c b]]
+func Permutations[V any](it *Iterator[V], k int, opts ...CombinatoricsOption) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			pool := it.Collect()
+			n := len(pool)
+			if it.Err() != nil || k < 0 || k > n {
+				return
+			}
+			e := newIndexEmitter(pool, opts)
+
+			indices := make([]int, n)
+			for i := range indices {
+				indices[i] = i
+			}
+			cycles := make([]int, k)
+			for i := range cycles {
+				cycles[i] = n - i
+			}
+			if !e.emit(indices[:k], yield) {
+				return
+			}
+			for {
+				i := k - 1
+				for ; i >= 0; i-- {
+					cycles[i]--
+					if cycles[i] == 0 {
+						// Rotate indices[i:] left by one
+						first := indices[i]
+						copy(indices[i:], indices[i+1:])
+						indices[n-1] = first
+						cycles[i] = n - i
+						continue
+					}
+					j := n - cycles[i]
+					indices[i], indices[j] = indices[j], indices[i]
+					if !e.emit(indices[:k], yield) {
+						return
+					}
+					break
+				}
+				if i < 0 {
+					return
+				}
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// Combinations returns an iterator over all k-element subsets of the
+// iterator's elements, in lexicographic order of position, like Python's
+// itertools.combinations. Each subset keeps the input order. Nothing is
+// yielded if k is negative or larger than the number of elements.
+//
+// Note: The input is collected into memory when iteration starts. The
+// combinations themselves are generated lazily.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3, 4})
+//	pairs := itertools.Combinations(iter, 2).Collect()
+//	// pairs is [[1 2] [1 3] [1 4] [2 3] [2 4] [3 4]]
+func Combinations[V any](it *Iterator[V], k int, opts ...CombinatoricsOption) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			pool := it.Collect()
+			if it.Err() != nil {
+				return
+			}
+			combinations(newIndexEmitter(pool, opts), k, yield)
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// combinations yields the k-element combinations of e's pool. It returns
+// false if yield asked to stop.
+func combinations[V any](e *indexEmitter[V], k int, yield func([]V) bool) bool {
+	n := len(e.pool)
+	if k < 0 || k > n {
+		return true
+	}
+	indices := make([]int, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	if !e.emit(indices, yield) {
+		return false
+	}
+	for {
+		i := k - 1
+		for i >= 0 && indices[i] == i+n-k {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+		if !e.emit(indices, yield) {
+			return false
+		}
+	}
+}
+
+// CombinationsWithReplacement returns an iterator over all k-element
+// multisets of the iterator's elements, allowing an element to be chosen
+// more than once, like Python's itertools.combinations_with_replacement.
+// Nothing is yielded if k is negative, or if the iterator is empty and k is
+// positive.
+//
+// Note: The input is collected into memory when iteration starts. The
+// combinations themselves are generated lazily.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"a", "b", "c"})
+//	combos := itertools.CombinationsWithReplacement(iter, 2).Collect()
+//	// combos is [[a a] [a b] [a c] [b b] [b c] [c c]]
+func CombinationsWithReplacement[V any](it *Iterator[V], k int, opts ...CombinatoricsOption) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			pool := it.Collect()
+			n := len(pool)
+			if it.Err() != nil || k < 0 || (n == 0 && k > 0) {
+				return
+			}
+			e := newIndexEmitter(pool, opts)
+
+			indices := make([]int, k)
+			if !e.emit(indices, yield) {
+				return
+			}
+			for {
+				i := k - 1
+				for i >= 0 && indices[i] == n-1 {
+					i--
+				}
+				if i < 0 {
+					return
+				}
+				v := indices[i] + 1
+				for j := i; j < k; j++ {
+					indices[j] = v
+				}
+				if !e.emit(indices, yield) {
+					return
+				}
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// Powerset returns an iterator over all subsets of the iterator's elements,
+// from the empty set up to the full set, ordered by size and then
+// lexicographically by position. An input of n elements has 2^n subsets.
+//
+// Note: The input is collected into memory when iteration starts. The
+// subsets themselves are generated lazily.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3})
+//	subsets := itertools.Powerset(iter).Collect()
+//	// subsets is [[] [1] [2] [3] [1 2] [1 3] [2 3] [1 2 3]]
+func Powerset[V any](it *Iterator[V], opts ...CombinatoricsOption) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			pool := it.Collect()
+			if it.Err() != nil {
+				return
+			}
+			e := newIndexEmitter(pool, opts)
+			for k := 0; k <= len(pool); k++ {
+				if !combinations(e, k, yield) {
+					return
+				}
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// CartesianProductN returns an iterator over the Cartesian product of any
+// number of iterators, like Python's itertools.product. Each element is a
+// slice with one element from each input, and the last input varies fastest.
+// The product of no inputs is a single empty slice.
+//
+// Note: Every input but the first is collected into memory when iteration
+// starts; the first is streamed.
+//
+// Example:
+//
+//	sizes := itertools.ToIter([]string{"S", "L"})
+//	colors := itertools.ToIter([]string{"red", "blue"})
+//	matrix := itertools.CartesianProductN([]*itertools.Iterator[string]{sizes, colors}).Collect()
+//	// matrix is [[S red] [S blue] [L red] [L blue]]
+func CartesianProductN[V any](its []*Iterator[V], opts ...CombinatoricsOption) *Iterator[[]V] {
+	errs := make([]func() error, len(its))
+	closers := make([]func() error, len(its))
+	for i, it := range its {
+		errs[i] = it.Err
+		closers[i] = it.Close
+	}
+	var cfg combinatoricsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			if len(its) == 0 {
+				yield([]V{})
+				return
+			}
+
+			pools := make([][]V, len(its)-1)
+			for i, it := range its[1:] {
+				if pools[i] = it.Collect(); it.Err() != nil || len(pools[i]) == 0 {
+					return
+				}
+			}
+
+			var buf []V
+			indices := make([]int, len(pools))
+			its[0].seq(func(first V) bool {
+				clear(indices)
+				for {
+					out := buf[:0]
+					if !cfg.reuse || out == nil {
+						out = make([]V, 0, len(its))
+					}
+					out = append(out, first)
+					for i, j := range indices {
+						out = append(out, pools[i][j])
+					}
+					if cfg.reuse {
+						buf = out
+					}
+					if !yield(out) {
+						return false
+					}
+
+					// Advance the odometer, rightmost input first
+					i := len(indices) - 1
+					for ; i >= 0; i-- {
+						if indices[i]++; indices[i] < len(pools[i]) {
+							break
+						}
+						indices[i] = 0
+					}
+					if i < 0 {
+						return true
+					}
+				}
+			})
+		},
+		err:    firstErr(errs...),
+		closer: closeAll(closers...),
+	}
+}
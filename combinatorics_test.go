@@ -0,0 +1,145 @@
+package itertools_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermutations(t *testing.T) {
+	perms := itertools.Permutations(itertools.ToIter([]string{"a", "b", "c"}), 2).Collect()
+
+	assert.Equal(t, [][]string{
+		{"a", "b"}, {"a", "c"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"},
+	}, perms)
+}
+
+func TestPermutations_Full(t *testing.T) {
+	perms := itertools.Permutations(itertools.Range(0, 3), 3).Collect()
+
+	assert.Equal(t, [][]int{
+		{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+	}, perms)
+	assert.Equal(t, 120, itertools.Permutations(itertools.Range(0, 5), 5).Count())
+	assert.Equal(t, 60, itertools.Permutations(itertools.Range(0, 5), 3).Count())
+}
+
+func TestPermutations_EdgeCases(t *testing.T) {
+	assert.Equal(t, [][]int{{}}, itertools.Permutations(itertools.Range(0, 3), 0).Collect())
+	assert.Empty(t, itertools.Permutations(itertools.Range(0, 3), 4).Collect())
+	assert.Empty(t, itertools.Permutations(itertools.Range(0, 3), -1).Collect())
+}
+
+func TestCombinations(t *testing.T) {
+	pairs := itertools.Combinations(itertools.ToIter([]int{1, 2, 3, 4}), 2).Collect()
+
+	assert.Equal(t, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, pairs)
+	assert.Equal(t, 252, itertools.Combinations(itertools.Range(0, 10), 5).Count())
+	assert.Equal(t, [][]int{{}}, itertools.Combinations(itertools.Range(0, 3), 0).Collect())
+	assert.Empty(t, itertools.Combinations(itertools.Range(0, 3), 4).Collect())
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	combos := itertools.CombinationsWithReplacement(itertools.ToIter([]string{"a", "b", "c"}), 2).Collect()
+
+	assert.Equal(t, [][]string{
+		{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"},
+	}, combos)
+	assert.Equal(t, 35, itertools.CombinationsWithReplacement(itertools.Range(0, 5), 3).Count())
+	assert.Empty(t, itertools.CombinationsWithReplacement(itertools.ToIter([]int{}), 2).Collect())
+	assert.Equal(t, [][]int{{}}, itertools.CombinationsWithReplacement(itertools.ToIter([]int{}), 0).Collect())
+}
+
+func TestPowerset(t *testing.T) {
+	subsets := itertools.Powerset(itertools.ToIter([]int{1, 2, 3})).Collect()
+
+	assert.Equal(t, [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, subsets)
+	assert.Equal(t, 1024, itertools.Powerset(itertools.Range(0, 10)).Count())
+	assert.Equal(t, [][]int{{}}, itertools.Powerset(itertools.ToIter([]int{})).Collect())
+}
+
+func TestCombinatorics_EarlyTermination(t *testing.T) {
+	// 20! permutations can only be enumerated lazily
+	first := itertools.Permutations(itertools.Range(0, 20), 20).Take(2).Collect()
+
+	assert.Equal(t, 2, len(first))
+	assert.Equal(t, 19, first[1][18])
+	assert.Equal(t, 18, first[1][19])
+	assert.Equal(t, 3, len(itertools.Powerset(itertools.Range(0, 40)).Take(3).Collect()))
+}
+
+func TestCombinatorics_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	combos := itertools.Combinations(itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken}), 2)
+
+	assert.Empty(t, combos.Collect())
+	assert.ErrorIs(t, combos.Err(), errBroken)
+}
+
+func TestWithReuseBuffer(t *testing.T) {
+	var firsts []*int
+	sum := 0
+	for combo := range itertools.Combinations(itertools.Range(0, 5), 3, itertools.WithReuseBuffer()).Seq() {
+		firsts = append(firsts, &combo[0])
+		sum += combo[0] + combo[1] + combo[2]
+	}
+
+	assert.Equal(t, 60, sum)
+	for _, p := range firsts {
+		assert.Same(t, firsts[0], p)
+	}
+
+	reused := testing.AllocsPerRun(10, func() {
+		itertools.Permutations(itertools.Range(0, 8), 4, itertools.WithReuseBuffer()).Count()
+	})
+	fresh := testing.AllocsPerRun(10, func() {
+		itertools.Permutations(itertools.Range(0, 8), 4).Count()
+	})
+	assert.Less(t, reused, fresh/10)
+}
+
+func TestCartesianProductN(t *testing.T) {
+	sizes := itertools.ToIter([]string{"S", "L"})
+	colors := itertools.ToIter([]string{"red", "blue"})
+	fits := itertools.ToIter([]string{"slim", "regular", "loose"})
+
+	matrix := itertools.CartesianProductN([]*itertools.Iterator[string]{sizes, colors, fits}).Collect()
+
+	assert.Equal(t, 12, len(matrix))
+	assert.Equal(t, []string{"S", "red", "slim"}, matrix[0])
+	assert.Equal(t, []string{"S", "red", "regular"}, matrix[1])
+	assert.Equal(t, []string{"S", "blue", "slim"}, matrix[3])
+	assert.Equal(t, []string{"L", "blue", "loose"}, matrix[11])
+}
+
+func TestCartesianProductN_EdgeCases(t *testing.T) {
+	assert.Equal(t, [][]int{{}}, itertools.CartesianProductN([]*itertools.Iterator[int]{}).Collect())
+	assert.Equal(t, [][]int{{0}, {1}}, itertools.CartesianProductN([]*itertools.Iterator[int]{itertools.Range(0, 2)}).Collect())
+
+	withEmpty := []*itertools.Iterator[int]{itertools.Range(0, 2), itertools.ToIter([]int{}), itertools.Range(0, 2)}
+	assert.Empty(t, itertools.CartesianProductN(withEmpty).Collect())
+}
+
+func TestCartesianProductN_StreamsFirstInput(t *testing.T) {
+	n := 0
+	counter := itertools.Generate(func() int { n++; return n })
+	bits := itertools.Range(0, 2)
+
+	var result [][]int
+	for xs := range itertools.CartesianProductN([]*itertools.Iterator[int]{counter, bits}, itertools.WithReuseBuffer()).Take(3).Seq() {
+		result = append(result, append([]int(nil), xs...))
+	}
+
+	assert.Equal(t, [][]int{{1, 0}, {1, 1}, {2, 0}}, result)
+}
+
+func TestCartesianProductN_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "a\n", err: errBroken})
+	product := itertools.CartesianProductN([]*itertools.Iterator[string]{itertools.ToIter([]string{"x"}), lines})
+
+	assert.Empty(t, product.Collect())
+	assert.ErrorIs(t, product.Err(), errBroken)
+}
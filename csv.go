@@ -1,15 +1,25 @@
 package itertools
 
 import (
+	"bufio"
 	"context"
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // FromCSV creates a lazy Iterator that reads records from a CSV reader.
 // Each element is a []string representing one CSV row.
 // This is useful for processing large CSV files without loading them entirely into memory.
 //
+// By default a malformed row or a read error stops iteration; the error,
+// usually a *csv.ParseError with the line and column, is reported by Err.
+// Use WithCSVErrorPolicy to skip or collect malformed rows instead.
+//
 // Example:
 //
 //	file, _ := os.Open("large_data.csv")
@@ -18,28 +28,16 @@ import (
 //	records := iter.Filter(func(row []string) bool {
 //	    return len(row) > 0 && row[0] != ""
 //	}).Take(100).Collect()
-func FromCSV(r *csv.Reader) *Iterator[[]string] {
-	return &Iterator[[]string]{
-		seq: func(yield func([]string) bool) {
-			for {
-				record, err := r.Read()
-				if err == io.EOF {
-					return
-				}
-				if err != nil {
-					// Skip malformed rows
-					continue
-				}
-				if !yield(record) {
-					return
-				}
-			}
-		},
-	}
+//	if err := iter.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func FromCSV(r *csv.Reader, opts ...CSVOption) *Iterator[[]string] {
+	return FromCSVWithContext(context.Background(), r, opts...)
 }
 
 // FromCSVWithContext creates a lazy Iterator from a CSV reader with context support.
 // The iterator will stop when either the CSV is exhausted or the context is cancelled.
+// Parse errors, read errors and the context's error are reported by Err.
 //
 // Example:
 //
@@ -49,35 +47,118 @@ func FromCSV(r *csv.Reader) *Iterator[[]string] {
 //	defer file.Close()
 //	iter := itertools.FromCSVWithContext(ctx, csv.NewReader(file))
 //	records := iter.Collect()
-func FromCSVWithContext(ctx context.Context, r *csv.Reader) *Iterator[[]string] {
+func FromCSVWithContext(ctx context.Context, r *csv.Reader, opts ...CSVOption) *Iterator[[]string] {
+	cfg := newCSVConfig(opts)
+	var err error
 	return &Iterator[[]string]{
 		seq: func(yield func([]string) bool) {
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					record, err := r.Read()
-					if err == io.EOF {
-						return
-					}
-					if err != nil {
-						// Skip malformed rows
-						continue
-					}
-					if !yield(record) {
-						return
-					}
+			err = readCSV(ctx, r, cfg, yield)
+		},
+		err: func() error { return err },
+	}
+}
+
+// readCSV reads records from r and passes them to yield until the input is
+// exhausted, yield returns false, or ctx is cancelled. Malformed records are
+// handled according to cfg. It returns the error that stopped reading, or
+// nil on io.EOF and early termination.
+func readCSV(ctx context.Context, r *csv.Reader, cfg *csvConfig, yield func([]string) bool) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			record, err := r.Read()
+			if err == io.EOF {
+				return nil
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				err = cfg.reject(CSVRejection{
+					Line:   parseErr.Line,
+					Column: parseErr.Column,
+					Record: record,
+					Reason: parseErr.Err.Error(),
+					Err:    err,
+				})
+				if err == nil {
+					continue
 				}
 			}
-		},
+			if err != nil {
+				return err
+			}
+			if !yield(record) {
+				return nil
+			}
+		}
+	}
+}
+
+// ErrDuplicateCSVHeader is reported when a CSV header names the same column
+// twice.
+var ErrDuplicateCSVHeader = errors.New("itertools: duplicate csv header")
+
+// ErrMissingCSVColumn is reported when a required column is not in the CSV
+// header, or a row has no value for it.
+var ErrMissingCSVColumn = errors.New("itertools: missing csv column")
+
+// CSVHeader indexes the columns of a CSV header row by name, so that fields
+// can be looked up in constant time.
+type CSVHeader struct {
+	names []string
+	index map[string]int
+}
+
+// NewCSVHeader builds a header index from the column names. It returns an
+// error wrapping ErrDuplicateCSVHeader if a name appears more than once.
+//
+// Example:
+//
+//	header, err := itertools.NewCSVHeader([]string{"id", "name"})
+//	i, ok := header.Index("name")
+//	// i is 1, ok is true
+func NewCSVHeader(names []string) (*CSVHeader, error) {
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateCSVHeader, name)
+		}
+		index[name] = i
+	}
+	return &CSVHeader{names: names, index: index}, nil
+}
+
+// Names returns the column names in header order.
+func (h *CSVHeader) Names() []string {
+	return h.names
+}
+
+// Index returns the position of the named column, and false if the header
+// has no such column.
+func (h *CSVHeader) Index(name string) (int, bool) {
+	i, ok := h.index[name]
+	return i, ok
+}
+
+// Require returns an error wrapping ErrMissingCSVColumn naming the first
+// column that is not in the header.
+func (h *CSVHeader) Require(names ...string) error {
+	for _, name := range names {
+		if _, ok := h.index[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrMissingCSVColumn, name)
+		}
 	}
+	return nil
 }
 
 // CSVRow represents a parsed CSV row with helper methods
 type CSVRow struct {
 	Fields []string
 	Index  int
+	// Header is the header of the file the row was read from. It is set by
+	// FromCSVWithHeaders and used by the name-based accessors.
+	Header *CSVHeader
 }
 
 // Get returns the field at the given index, or empty string if out of bounds
@@ -90,6 +171,10 @@ func (r CSVRow) Get(index int) string {
 
 // GetByHeader returns the field with the given header name
 // headers should be the first row of the CSV
+//
+// Note: GetByHeader searches headers linearly. Rows read with
+// FromCSVWithHeaders carry their Header, so Lookup and the typed getters
+// find columns in constant time.
 func (r CSVRow) GetByHeader(headers []string, name string) string {
 	for i, h := range headers {
 		if h == name {
@@ -99,46 +184,291 @@ func (r CSVRow) GetByHeader(headers []string, name string) string {
 	return ""
 }
 
+// Lookup returns the field in the named column. The boolean is false if the
+// row has no header, the header has no such column, or the row is too short
+// to have a value for it.
+//
+// Example:
+//
+//	if email, ok := row.Lookup("email"); ok {
+//	    send(email)
+//	}
+func (r CSVRow) Lookup(name string) (string, bool) {
+	if r.Header == nil {
+		return "", false
+	}
+	i, ok := r.Header.index[name]
+	if !ok || i >= len(r.Fields) {
+		return "", false
+	}
+	return r.Fields[i], true
+}
+
+// Int parses the field in the named column as a base 10 integer.
+// It returns a *CSVFieldError if the column is missing or the value is not
+// an integer.
+//
+// Example:
+//
+//	age, err := row.Int("age")
+func (r CSVRow) Int(name string) (int, error) {
+	s, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, r.fieldError(name, err)
+	}
+	return n, nil
+}
+
+// Float parses the field in the named column as a float64.
+// It returns a *CSVFieldError if the column is missing or the value is not
+// a number.
+//
+// Example:
+//
+//	amount, err := row.Float("amount")
+func (r CSVRow) Float(name string) (float64, error) {
+	s, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, r.fieldError(name, err)
+	}
+	return f, nil
+}
+
+// Bool parses the field in the named column with strconv.ParseBool.
+// It returns a *CSVFieldError if the column is missing or the value is not
+// a boolean.
+//
+// Example:
+//
+//	active, err := row.Bool("active")
+func (r CSVRow) Bool(name string) (bool, error) {
+	s, err := r.value(name)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, r.fieldError(name, err)
+	}
+	return b, nil
+}
+
+// Time parses the field in the named column with the given layout.
+// It returns a *CSVFieldError if the column is missing or the value does not
+// match the layout.
+//
+// Example:
+//
+//	created, err := row.Time("created_at", time.DateOnly)
+func (r CSVRow) Time(name, layout string) (time.Time, error) {
+	s, err := r.value(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, r.fieldError(name, err)
+	}
+	return t, nil
+}
+
+// value returns the field in the named column, or a *CSVFieldError wrapping
+// ErrMissingCSVColumn.
+func (r CSVRow) value(name string) (string, error) {
+	s, ok := r.Lookup(name)
+	if !ok {
+		return "", r.fieldError(name, ErrMissingCSVColumn)
+	}
+	return s, nil
+}
+
+func (r CSVRow) fieldError(name string, err error) error {
+	return &CSVFieldError{Row: r.Index, Column: name, Err: err}
+}
+
+// CSVOption configures the CSV sources.
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+	required   []string
+	policy     CSVErrorPolicy
+	report     *CSVReport
+	deadLetter []func(CSVRejection) error
+}
+
+func newCSVConfig(opts []CSVOption) *csvConfig {
+	cfg := &csvConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithRequiredHeaders makes FromCSVWithHeaders and FromCSVAs return an error
+// wrapping ErrMissingCSVColumn if any of the named columns is not in the
+// header.
+func WithRequiredHeaders(names ...string) CSVOption {
+	return func(c *csvConfig) {
+		c.required = append(c.required, names...)
+	}
+}
+
+// CSVErrorPolicy decides what the CSV sources do with a malformed row, or,
+// for FromCSVAs, a row with a value that cannot be converted.
+type CSVErrorPolicy int
+
+const (
+	// CSVFail stops iteration and reports the error through Err. It is the
+	// default.
+	CSVFail CSVErrorPolicy = iota
+	// CSVSkip drops the row and carries on.
+	CSVSkip
+	// CSVCollect drops the row, carries on, and records it in the report set
+	// with WithCSVReport.
+	CSVCollect
+)
+
+// CSVRejection describes a row that was rejected by a CSV source.
+type CSVRejection struct {
+	// Line and Column locate the error in the input, both 1-based.
+	Line   int
+	Column int
+	// Record holds the fields read from the row. After a quoting error it
+	// holds only the fields before the error.
+	Record []string
+	// Reason is a short description of what is wrong with the row.
+	Reason string
+	// Err is the full error, a *csv.ParseError or a *CSVFieldError.
+	Err error
+}
+
+// CSVReport collects the rows rejected under the CSVCollect policy.
+type CSVReport struct {
+	Rejections []CSVRejection
+}
+
+// Err joins the errors of all rejections, or returns nil if no row was
+// rejected.
+func (r *CSVReport) Err() error {
+	errs := make([]error, len(r.Rejections))
+	for i, rej := range r.Rejections {
+		errs[i] = rej.Err
+	}
+	return errors.Join(errs...)
+}
+
+// WithCSVErrorPolicy sets what happens to malformed rows. Read errors from
+// the underlying reader always stop iteration.
+func WithCSVErrorPolicy(policy CSVErrorPolicy) CSVOption {
+	return func(c *csvConfig) {
+		c.policy = policy
+	}
+}
+
+// WithCSVReport sets the CSVCollect policy and records every rejected row
+// in report.
+//
+// Example:
+//
+//	var report itertools.CSVReport
+//	iter := itertools.FromCSV(csv.NewReader(file), itertools.WithCSVReport(&report))
+//	rows := iter.Collect()
+//	for _, rej := range report.Rejections {
+//	    log.Printf("line %d, column %d: %s", rej.Line, rej.Column, rej.Reason)
+//	}
+func WithCSVReport(report *CSVReport) CSVOption {
+	return func(c *csvConfig) {
+		c.policy = CSVCollect
+		c.report = report
+	}
+}
+
+// WithCSVDeadLetter calls fn for every row dropped under the CSVSkip or
+// CSVCollect policy.
+func WithCSVDeadLetter(fn func(CSVRejection)) CSVOption {
+	return func(c *csvConfig) {
+		c.deadLetter = append(c.deadLetter, func(rej CSVRejection) error {
+			fn(rej)
+			return nil
+		})
+	}
+}
+
+// WithCSVDeadLetterWriter writes the fields of every row dropped under the
+// CSVSkip or CSVCollect policy to w, flushing after each row. A write error
+// stops iteration.
+//
+// Example:
+//
+//	rejected, _ := os.Create("rejected.csv")
+//	defer rejected.Close()
+//	iter := itertools.FromCSV(csv.NewReader(file),
+//	    itertools.WithCSVErrorPolicy(itertools.CSVSkip),
+//	    itertools.WithCSVDeadLetterWriter(csv.NewWriter(rejected)))
+func WithCSVDeadLetterWriter(w *csv.Writer) CSVOption {
+	return func(c *csvConfig) {
+		c.deadLetter = append(c.deadLetter, func(rej CSVRejection) error {
+			if err := w.Write(rej.Record); err != nil {
+				return err
+			}
+			w.Flush()
+			return w.Error()
+		})
+	}
+}
+
+// reject applies the error policy to a rejected row. It returns nil if the
+// row should be skipped, or the error that should stop iteration.
+func (c *csvConfig) reject(rej CSVRejection) error {
+	if c.policy == CSVFail {
+		return rej.Err
+	}
+	if c.policy == CSVCollect && c.report != nil {
+		c.report.Rejections = append(c.report.Rejections, rej)
+	}
+	for _, fn := range c.deadLetter {
+		if err := fn(rej); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // FromCSVWithHeaders creates a lazy Iterator that reads CSV records with header support.
 // The first row is treated as headers and subsequent rows are wrapped in CSVRow for easier access.
 // Returns the iterator and the header row.
 //
+// Each row carries a CSVHeader index, so the name-based accessors such as
+// Lookup, Int and Time do not scan the header. A header that names the same
+// column twice is an error wrapping ErrDuplicateCSVHeader; use
+// WithRequiredHeaders to also check for missing columns up front.
+//
+// Errors after the header row stop iteration and are reported by the iterator's Err,
+// unless WithCSVErrorPolicy says to skip or collect malformed rows.
+//
 // Example:
 //
 //	file, _ := os.Open("data.csv")
 //	defer file.Close()
-//	iter, headers := itertools.FromCSVWithHeaders(csv.NewReader(file))
+//	iter, _, err := itertools.FromCSVWithHeaders(csv.NewReader(file),
+//	    itertools.WithRequiredHeaders("age"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
 //	records := iter.Filter(func(row CSVRow) bool {
-//	    age := row.GetByHeader(headers, "age")
-//	    return age != "" && age > "30"
+//	    age, err := row.Int("age")
+//	    return err == nil && age > 30
 //	}).Collect()
-func FromCSVWithHeaders(r *csv.Reader) (*Iterator[CSVRow], []string, error) {
-	// Read header row
-	headers, err := r.Read()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	index := 0
-	iter := &Iterator[CSVRow]{
-		seq: func(yield func(CSVRow) bool) {
-			for {
-				record, err := r.Read()
-				if err == io.EOF {
-					return
-				}
-				if err != nil {
-					continue
-				}
-				if !yield(CSVRow{Fields: record, Index: index}) {
-					return
-				}
-				index++
-			}
-		},
-	}
-
-	return iter, headers, nil
+func FromCSVWithHeaders(r *csv.Reader, opts ...CSVOption) (*Iterator[CSVRow], []string, error) {
+	return FromCSVWithHeadersContext(context.Background(), r, opts...)
 }
 
 // FromCSVWithHeadersContext creates a lazy Iterator from CSV with headers and context support.
@@ -150,36 +480,207 @@ func FromCSVWithHeaders(r *csv.Reader) (*Iterator[CSVRow], []string, error) {
 //	file, _ := os.Open("large.csv")
 //	defer file.Close()
 //	iter, headers, _ := itertools.FromCSVWithHeadersContext(ctx, csv.NewReader(file))
-func FromCSVWithHeadersContext(ctx context.Context, r *csv.Reader) (*Iterator[CSVRow], []string, error) {
+func FromCSVWithHeadersContext(ctx context.Context, r *csv.Reader, opts ...CSVOption) (*Iterator[CSVRow], []string, error) {
+	iter, header, err := fromCSVWithHeader(ctx, r, newCSVConfig(opts))
+	if err != nil {
+		return nil, nil, err
+	}
+	return iter, header.names, nil
+}
+
+// fromCSVWithHeader reads and checks the header row of r and returns an
+// iterator over the remaining rows.
+func fromCSVWithHeader(ctx context.Context, r *csv.Reader, cfg *csvConfig) (*Iterator[CSVRow], *CSVHeader, error) {
 	// Read header row
 	headers, err := r.Read()
 	if err != nil {
 		return nil, nil, err
 	}
+	header, err := NewCSVHeader(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := header.Require(cfg.required...); err != nil {
+		return nil, nil, err
+	}
 
 	index := 0
+	var iterErr error
 	iter := &Iterator[CSVRow]{
 		seq: func(yield func(CSVRow) bool) {
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					record, err := r.Read()
-					if err == io.EOF {
-						return
-					}
-					if err != nil {
-						continue
-					}
-					if !yield(CSVRow{Fields: record, Index: index}) {
-						return
-					}
-					index++
+			iterErr = readCSV(ctx, r, cfg, func(record []string) bool {
+				if !yield(CSVRow{Fields: record, Index: index, Header: header}) {
+					return false
 				}
-			}
+				index++
+				return true
+			})
 		},
+		err: func() error { return iterErr },
+	}
+
+	return iter, header, nil
+}
+
+// CSVRecord is the set of element types that ToCSV and ToCSVDialect can write.
+type CSVRecord interface {
+	[]string | CSVRow
+}
+
+// CSVWriteOption configures ToCSV and ToCSVDialect.
+type CSVWriteOption func(*csvWriteConfig)
+
+type csvWriteConfig struct {
+	flushEvery int
+}
+
+// WithFlushEvery makes ToCSV and ToCSVDialect flush the output after every
+// n records. The default is 1000. The output is always flushed at the end.
+func WithFlushEvery(n int) CSVWriteOption {
+	return func(c *csvWriteConfig) {
+		c.flushEvery = max(n, 1)
 	}
+}
 
-	return iter, headers, nil
+// ToCSV consumes the iterator and writes each record to w, preceded by the
+// headers row if headers is not nil. The delimiter and line endings are
+// taken from w.Comma and w.UseCRLF.
+//
+// It returns the first write error, or the error that stopped the iterator.
+//
+// Example:
+//
+//	in, headers, _ := itertools.FromCSVWithHeaders(csv.NewReader(src))
+//	active := in.Filter(func(row itertools.CSVRow) bool {
+//	    return row.GetByHeader(headers, "status") == "active"
+//	})
+//	w := csv.NewWriter(dst)
+//	w.Comma = ';'
+//	if err := itertools.ToCSV(active, w, headers); err != nil {
+//	    log.Fatal(err)
+//	}
+func ToCSV[R CSVRecord](it *Iterator[R], w *csv.Writer, headers []string, opts ...CSVWriteOption) error {
+	flush := func() error {
+		w.Flush()
+		return w.Error()
+	}
+	return writeCSV(it, headers, w.Write, flush, opts)
+}
+
+// CSVDialect describes the format written by ToCSVDialect.
+type CSVDialect struct {
+	// Comma is the field delimiter. The default is ','.
+	Comma rune
+	// Quote is the quote character. The default is '"'.
+	Quote rune
+	// QuoteAll quotes every field, not only the ones that need it.
+	QuoteAll bool
+	// UseCRLF ends lines with \r\n instead of \n.
+	UseCRLF bool
+}
+
+// ToCSVDialect is like ToCSV, but writes to an io.Writer using the given
+// dialect. It is useful when the output requires a quoting style that
+// encoding/csv does not support, such as quoting every field.
+//
+// Example:
+//
+//	d := itertools.CSVDialect{Comma: '\t', QuoteAll: true}
+//	err := itertools.ToCSVDialect(rows, os.Stdout, []string{"id", "name"}, d)
+func ToCSVDialect[R CSVRecord](it *Iterator[R], w io.Writer, headers []string, d CSVDialect, opts ...CSVWriteOption) error {
+	if d.Comma == 0 {
+		d.Comma = ','
+	}
+	if d.Quote == 0 {
+		d.Quote = '"'
+	}
+	bw := bufio.NewWriter(w)
+	write := func(fields []string) error {
+		return d.writeRecord(bw, fields)
+	}
+	return writeCSV(it, headers, write, bw.Flush, opts)
+}
+
+// writeRecord writes one record in the dialect's format.
+func (d CSVDialect) writeRecord(w *bufio.Writer, fields []string) error {
+	quote := string(d.Quote)
+	for i, field := range fields {
+		if i > 0 {
+			if _, err := w.WriteRune(d.Comma); err != nil {
+				return err
+			}
+		}
+		if !d.QuoteAll && !d.needsQuotes(field) {
+			if _, err := w.WriteString(field); err != nil {
+				return err
+			}
+			continue
+		}
+		escaped := strings.ReplaceAll(field, quote, quote+quote)
+		if _, err := w.WriteString(quote + escaped + quote); err != nil {
+			return err
+		}
+	}
+	eol := "\n"
+	if d.UseCRLF {
+		eol = "\r\n"
+	}
+	_, err := w.WriteString(eol)
+	return err
+}
+
+// needsQuotes reports whether field must be quoted to be read back intact.
+func (d CSVDialect) needsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+	return strings.ContainsRune(field, d.Comma) ||
+		strings.ContainsRune(field, d.Quote) ||
+		strings.ContainsAny(field, "\r\n")
+}
+
+// writeCSV writes the headers and every record of it using write, flushing
+// periodically. It returns the first error from write or flush, or the
+// iterator's error.
+func writeCSV[R CSVRecord](it *Iterator[R], headers []string, write func([]string) error, flush func() error, opts []CSVWriteOption) error {
+	cfg := csvWriteConfig{flushEvery: 1000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if headers != nil {
+		if err := write(headers); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	count := 0
+	it.seq(func(r R) bool {
+		var fields []string
+		switch record := any(r).(type) {
+		case []string:
+			fields = record
+		case CSVRow:
+			fields = record.Fields
+		}
+		if err = write(fields); err != nil {
+			return false
+		}
+		count++
+		if count%cfg.flushEvery == 0 {
+			err = flush()
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	return it.Err()
 }
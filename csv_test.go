@@ -3,6 +3,8 @@ package itertools_test
 import (
 	"context"
 	"encoding/csv"
+	"errors"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -237,9 +239,9 @@ func TestFromCSV_LargeFile(t *testing.T) {
 	sb.WriteString("id,name,value\n")
 	for i := 0; i < 10000; i++ {
 		sb.WriteString(strings.Join([]string{
-			string(rune(i)),
-			"item" + string(rune(i)),
-			string(rune(i * 10)),
+			strconv.Itoa(i),
+			"item" + strconv.Itoa(i),
+			strconv.Itoa(i * 10),
 		}, ","))
 		sb.WriteString("\n")
 	}
@@ -255,4 +257,389 @@ func TestFromCSV_LargeFile(t *testing.T) {
 		Collect()
 
 	assert.Equal(t, 10, len(samples))
+	assert.Equal(t, []string{"100", "item100", "1000"}, samples[1])
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromCSV_MalformedRowStopsWithError(t *testing.T) {
+	csvData := `name,age,city
+Alice,30,NYC
+Bob,25
+Charlie,35,Chicago`
+
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(csvData)))
+	records := iter.Collect()
+
+	assert.Equal(t, 2, len(records))
+
+	var parseErr *csv.ParseError
+	assert.True(t, errors.As(iter.Err(), &parseErr))
+	assert.Equal(t, 3, parseErr.Line)
+	assert.ErrorIs(t, iter.Err(), csv.ErrFieldCount)
+}
+
+func TestFromCSV_ErrorPropagatesThroughOperators(t *testing.T) {
+	csvData := "a,b\n\"unterminated,c\n"
+
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(csvData))).
+		Filter(func(row []string) bool { return true }).
+		Map(func(row []string) []string { return row })
+	records := iter.Collect()
+
+	assert.Equal(t, [][]string{{"a", "b"}}, records)
+	assert.ErrorIs(t, iter.Err(), csv.ErrQuote)
+}
+
+func TestFromCSVWithHeaders_Err(t *testing.T) {
+	csvData := `name,age
+Alice,30
+Bob`
+
+	iter, _, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	records := iter.Collect()
+	assert.Equal(t, 1, len(records))
+	assert.ErrorIs(t, iter.Err(), csv.ErrFieldCount)
+}
+
+func TestFromCSVWithContext_Err(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter := itertools.FromCSVWithContext(ctx, csv.NewReader(strings.NewReader("a,b\n")))
+	iter.Collect()
+
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestToCSV(t *testing.T) {
+	var buf strings.Builder
+	iter := itertools.ToIter([][]string{{"Alice", "30"}, {"Bob", "25"}})
+
+	err := itertools.ToCSV(iter, csv.NewWriter(&buf), []string{"name", "age"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "name,age\nAlice,30\nBob,25\n", buf.String())
+}
+
+func TestToCSV_Rows(t *testing.T) {
+	csvData := `name,age,city
+Alice,30,NYC
+Bob,25,LA
+Charlie,35,Chicago`
+
+	iter, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	notLA := iter.Filter(func(row itertools.CSVRow) bool {
+		return row.GetByHeader(headers, "city") != "LA"
+	})
+
+	assert.NoError(t, itertools.ToCSV(notLA, w, headers))
+	assert.Equal(t, "name;age;city\nAlice;30;NYC\nCharlie;35;Chicago\n", buf.String())
+}
+
+func TestToCSV_NoHeaders(t *testing.T) {
+	var buf strings.Builder
+	iter := itertools.ToIter([][]string{{"a, b", `say "hi"`}})
+
+	err := itertools.ToCSV(iter, csv.NewWriter(&buf), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "\"a, b\",\"say \"\"hi\"\"\"\n", buf.String())
+}
+
+type failingWriter struct {
+	limit int
+	n     int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n+len(p) > w.limit {
+		return 0, errors.New("disk full")
+	}
+	w.n += len(p)
+	return len(p), nil
+}
+
+func TestToCSV_WriteError(t *testing.T) {
+	rows := itertools.MapTo(itertools.Range(0, 100), func(i int) []string {
+		return []string{strconv.Itoa(i)}
+	})
+	// Only a few rows fit, so one of the periodic flushes fails
+	err := itertools.ToCSV(rows, csv.NewWriter(&failingWriter{limit: 50}), nil, itertools.WithFlushEvery(10))
+
+	assert.ErrorContains(t, err, "disk full")
+}
+
+func TestToCSV_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	rows := itertools.FromCSV(csv.NewReader(&errReader{data: "a,b\n", err: errBroken}))
+
+	var buf strings.Builder
+	err := itertools.ToCSV(rows, csv.NewWriter(&buf), nil)
+
+	assert.ErrorIs(t, err, errBroken)
+	assert.Equal(t, "a,b\n", buf.String())
+}
+
+func TestToCSVDialect(t *testing.T) {
+	var buf strings.Builder
+	iter := itertools.ToIter([][]string{{"1", "it's"}, {"2", ""}})
+	d := itertools.CSVDialect{Comma: '\t', Quote: '\'', QuoteAll: true, UseCRLF: true}
+
+	err := itertools.ToCSVDialect(iter, &buf, []string{"id", "text"}, d)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "'id'\t'text'\r\n'1'\t'it''s'\r\n'2'\t''\r\n", buf.String())
+}
+
+func TestToCSVDialect_RoundTrip(t *testing.T) {
+	records := [][]string{{"a", "b,c"}, {" lead", "multi\nline"}, {`"q"`, ""}}
+
+	var buf strings.Builder
+	err := itertools.ToCSVDialect(itertools.ToIter(records), &buf, nil, itertools.CSVDialect{})
+	assert.NoError(t, err)
+
+	result := itertools.FromCSV(csv.NewReader(strings.NewReader(buf.String()))).Collect()
+	assert.Equal(t, records, result)
+}
+
+func TestNewCSVHeader(t *testing.T) {
+	header, err := itertools.NewCSVHeader([]string{"id", "name", "age"})
+	assert.NoError(t, err)
+
+	i, ok := header.Index("age")
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+
+	_, ok = header.Index("email")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"id", "name", "age"}, header.Names())
+	assert.NoError(t, header.Require("id", "age"))
+	assert.ErrorIs(t, header.Require("id", "email"), itertools.ErrMissingCSVColumn)
+}
+
+func TestNewCSVHeader_Duplicate(t *testing.T) {
+	_, err := itertools.NewCSVHeader([]string{"id", "name", "id"})
+
+	assert.ErrorIs(t, err, itertools.ErrDuplicateCSVHeader)
+	assert.ErrorContains(t, err, `"id"`)
+}
+
+func TestFromCSVWithHeaders_DuplicateHeader(t *testing.T) {
+	iter, _, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader("a,b,a\n1,2,3\n")))
+
+	assert.Nil(t, iter)
+	assert.ErrorIs(t, err, itertools.ErrDuplicateCSVHeader)
+}
+
+func TestFromCSVWithHeaders_RequiredHeaders(t *testing.T) {
+	csvData := "name,age\nAlice,30\n"
+
+	_, _, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)),
+		itertools.WithRequiredHeaders("name", "age"))
+	assert.NoError(t, err)
+
+	_, _, err = itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)),
+		itertools.WithRequiredHeaders("name", "email"))
+	assert.ErrorIs(t, err, itertools.ErrMissingCSVColumn)
+	assert.ErrorContains(t, err, `"email"`)
+}
+
+func TestCSVRow_Lookup(t *testing.T) {
+	csvData := `name,nickname,age
+Alice,,30
+Bob`
+
+	r := csv.NewReader(strings.NewReader(csvData))
+	r.FieldsPerRecord = -1
+	iter, _, err := itertools.FromCSVWithHeaders(r)
+	assert.NoError(t, err)
+	rows := iter.Collect()
+
+	nickname, ok := rows[0].Lookup("nickname")
+	assert.True(t, ok)
+	assert.Equal(t, "", nickname)
+
+	_, ok = rows[0].Lookup("email")
+	assert.False(t, ok)
+
+	// The second row is too short to have an age
+	_, ok = rows[1].Lookup("age")
+	assert.False(t, ok)
+
+	_, ok = itertools.CSVRow{Fields: []string{"x"}}.Lookup("name")
+	assert.False(t, ok)
+}
+
+func TestCSVRow_TypedGetters(t *testing.T) {
+	csvData := `id,price,active,created
+7,19.5,true,2024-03-01
+x,free,maybe,yesterday`
+
+	iter, _, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+	rows := iter.Collect()
+
+	id, err := rows[0].Int("id")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+
+	price, err := rows[0].Float("price")
+	assert.NoError(t, err)
+	assert.Equal(t, 19.5, price)
+
+	active, err := rows[0].Bool("active")
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	created, err := rows[0].Time("created", time.DateOnly)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), created)
+
+	var fieldErr *itertools.CSVFieldError
+	_, err = rows[1].Int("id")
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, 1, fieldErr.Row)
+	assert.Equal(t, "id", fieldErr.Column)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = rows[1].Float("price")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = rows[1].Bool("active")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = rows[1].Time("created", time.DateOnly)
+	assert.ErrorContains(t, err, `column "created"`)
+
+	_, err = rows[0].Int("quantity")
+	assert.ErrorIs(t, err, itertools.ErrMissingCSVColumn)
+}
+
+func TestCSVRow_GetByHeader_FromHeaders(t *testing.T) {
+	csvData := "a,b,c\n1,2,3\n"
+
+	iter, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+	row := iter.Collect()[0]
+
+	assert.Equal(t, "3", row.GetByHeader(headers, "c"))
+	assert.Equal(t, "", row.GetByHeader(headers, "d"))
+	// A different headers slice is still searched by name
+	assert.Equal(t, "1", row.GetByHeader([]string{"c", "b", "a"}, "c"))
+}
+
+const malformedCSV = `name,age,city
+Alice,30,NYC
+Bob,25
+Charlie,35,Chicago
+Eve,"4"2,Paris
+Dave,28,LA`
+
+func TestFromCSV_SkipPolicy(t *testing.T) {
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(malformedCSV)),
+		itertools.WithCSVErrorPolicy(itertools.CSVSkip))
+	records := iter.Collect()
+
+	assert.Equal(t, [][]string{
+		{"name", "age", "city"},
+		{"Alice", "30", "NYC"},
+		{"Charlie", "35", "Chicago"},
+		{"Dave", "28", "LA"},
+	}, records)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromCSV_CollectPolicy(t *testing.T) {
+	var report itertools.CSVReport
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(malformedCSV)), itertools.WithCSVReport(&report))
+	records := iter.Collect()
+
+	assert.Equal(t, 4, len(records))
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, 2, len(report.Rejections))
+
+	short := report.Rejections[0]
+	assert.Equal(t, 3, short.Line)
+	assert.Equal(t, 1, short.Column)
+	assert.Equal(t, []string{"Bob", "25"}, short.Record)
+	assert.Equal(t, csv.ErrFieldCount.Error(), short.Reason)
+	assert.ErrorIs(t, short.Err, csv.ErrFieldCount)
+
+	quote := report.Rejections[1]
+	assert.Equal(t, 5, quote.Line)
+	assert.Equal(t, 7, quote.Column)
+	assert.Equal(t, []string{"Eve"}, quote.Record)
+	assert.ErrorIs(t, quote.Err, csv.ErrQuote)
+
+	assert.ErrorIs(t, report.Err(), csv.ErrFieldCount)
+	assert.ErrorIs(t, report.Err(), csv.ErrQuote)
+}
+
+func TestFromCSV_DeadLetter(t *testing.T) {
+	var rejected []int
+	var buf strings.Builder
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(malformedCSV)),
+		itertools.WithCSVErrorPolicy(itertools.CSVSkip),
+		itertools.WithCSVDeadLetter(func(rej itertools.CSVRejection) {
+			rejected = append(rejected, rej.Line)
+		}),
+		itertools.WithCSVDeadLetterWriter(csv.NewWriter(&buf)))
+
+	assert.Equal(t, 4, iter.Count())
+	assert.Equal(t, []int{3, 5}, rejected)
+	// Only the fields before the bad quote are available
+	assert.Equal(t, "Bob,25\nEve\n", buf.String())
+}
+
+func TestFromCSV_DeadLetterWriteError(t *testing.T) {
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(malformedCSV)),
+		itertools.WithCSVErrorPolicy(itertools.CSVSkip),
+		itertools.WithCSVDeadLetterWriter(csv.NewWriter(&failingWriter{})))
+	records := iter.Collect()
+
+	assert.Equal(t, 2, len(records))
+	assert.ErrorContains(t, iter.Err(), "disk full")
+}
+
+func TestFromCSV_FailPolicyIsDefault(t *testing.T) {
+	var report itertools.CSVReport
+	iter := itertools.FromCSV(csv.NewReader(strings.NewReader(malformedCSV)),
+		itertools.WithCSVReport(&report),
+		itertools.WithCSVErrorPolicy(itertools.CSVFail))
+	records := iter.Collect()
+
+	assert.Equal(t, 2, len(records))
+	assert.ErrorIs(t, iter.Err(), csv.ErrFieldCount)
+	assert.Empty(t, report.Rejections)
+	assert.NoError(t, report.Err())
+}
+
+func TestFromCSV_PolicyKeepsReadErrors(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.FromCSV(csv.NewReader(&errReader{data: "a,b\n", err: errBroken}),
+		itertools.WithCSVErrorPolicy(itertools.CSVSkip))
+
+	assert.Equal(t, [][]string{{"a", "b"}}, iter.Collect())
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestFromCSVWithHeaders_CollectPolicy(t *testing.T) {
+	var report itertools.CSVReport
+	iter, _, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(malformedCSV)),
+		itertools.WithCSVReport(&report))
+	assert.NoError(t, err)
+
+	names := itertools.MapTo(iter, func(row itertools.CSVRow) string {
+		name, _ := row.Lookup("name")
+		return name
+	}).Collect()
+
+	assert.Equal(t, []string{"Alice", "Charlie", "Dave"}, names)
+	assert.Equal(t, 2, len(report.Rejections))
 }
@@ -0,0 +1,251 @@
+package itertools
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// CSVFieldError reports a CSV field that could not be converted. Row is the
+// 0-based index of the data row, as in CSVRow.Index, and Column is the header
+// name of the field.
+type CSVFieldError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *CSVFieldError) Error() string {
+	return fmt.Sprintf("itertools: csv row %d, column %q: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *CSVFieldError) Unwrap() error {
+	return e.Err
+}
+
+// FromCSVAs creates a lazy Iterator that decodes each CSV row into a struct
+// of type T. The first row is read as the header, and columns are matched to
+// exported fields by their `csv:"name"` tag, or by the field name when the
+// field has no tag. A field tagged `csv:"-"` is ignored.
+//
+// Fields may be strings, integers, floats, bools, time.Time, types that
+// implement encoding.TextUnmarshaler, or pointers to any of these. An empty
+// value leaves a pointer field nil. time.Time fields are parsed with the
+// layout given in a `layout:"..."` tag, or time.RFC3339 by default.
+//
+// It returns an error if the header cannot be read or names a column twice,
+// if T is not a struct, or if a tagged column or a column required with
+// WithRequiredHeaders is missing from the header. A value that cannot be
+// converted stops iteration, and Err returns a *CSVFieldError.
+//
+// Example:
+//
+//	type Sale struct {
+//	    ID     int       `csv:"id"`
+//	    Amount float64   `csv:"amount"`
+//	    Paid   bool      `csv:"paid"`
+//	    Date   time.Time `csv:"date" layout:"2006-01-02"`
+//	    Refund *float64  `csv:"refund"`
+//	}
+//	iter, err := itertools.FromCSVAs[Sale](csv.NewReader(file))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	paid := iter.Filter(func(s Sale) bool { return s.Paid }).Collect()
+//	if err := iter.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func FromCSVAs[T any](r *csv.Reader, opts ...CSVOption) (*Iterator[T], error) {
+	return FromCSVAsWithContext[T](context.Background(), r, opts...)
+}
+
+// FromCSVAsWithContext creates a lazy Iterator of decoded structs with context
+// support. The iterator will stop when either the CSV is exhausted or the
+// context is cancelled, in which case Err returns the context's error.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	iter, err := itertools.FromCSVAsWithContext[Sale](ctx, csv.NewReader(file))
+func FromCSVAsWithContext[T any](ctx context.Context, r *csv.Reader, opts ...CSVOption) (*Iterator[T], error) {
+	cfg := newCSVConfig(opts)
+	rows, header, err := fromCSVWithHeader(ctx, r, cfg)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := csvStructFields(reflect.TypeFor[T](), header)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodeErr error
+	return &Iterator[T]{
+		seq: func(yield func(T) bool) {
+			decodeErr = nil
+			rows.seq(func(row CSVRow) bool {
+				var v T
+				rv := reflect.ValueOf(&v).Elem()
+				for _, f := range fields {
+					if err := f.decode(row.Get(f.column), rv.Field(f.index)); err != nil {
+						// A short row has no position for the missing field
+						var line, column int
+						if f.column < len(row.Fields) {
+							line, column = r.FieldPos(f.column)
+						} else if len(row.Fields) > 0 {
+							line, _ = r.FieldPos(0)
+						}
+						decodeErr = cfg.reject(CSVRejection{
+							Line:   line,
+							Column: column,
+							Record: row.Fields,
+							Reason: err.Error(),
+							Err:    &CSVFieldError{Row: row.Index, Column: header.names[f.column], Err: err},
+						})
+						return decodeErr == nil
+					}
+				}
+				return yield(v)
+			})
+		},
+		err:    firstErr(func() error { return decodeErr }, rows.Err),
+		closer: rows.Close,
+	}, nil
+}
+
+// csvField maps a CSV column to a struct field.
+type csvField struct {
+	index  int
+	column int
+	decode func(string, reflect.Value) error
+}
+
+// csvStructFields resolves the fields of struct type t against the CSV
+// header.
+func csvStructFields(t reflect.Type, header *CSVHeader) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("itertools: FromCSVAs requires a struct type, got %s", t)
+	}
+
+	var fields []csvField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, tagged := sf.Tag.Lookup("csv")
+		if name == "-" {
+			continue
+		}
+		if !tagged || name == "" {
+			name = sf.Name
+		}
+		column, ok := header.Index(name)
+		if !ok {
+			if tagged {
+				return nil, fmt.Errorf("%w: %q for field %s", ErrMissingCSVColumn, name, sf.Name)
+			}
+			continue
+		}
+		decode, err := csvDecoder(sf.Type, sf.Tag.Get("layout"))
+		if err != nil {
+			return nil, fmt.Errorf("itertools: field %s: %w", sf.Name, err)
+		}
+		fields = append(fields, csvField{index: i, column: column, decode: decode})
+	}
+	return fields, nil
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// csvDecoder returns a function that parses a CSV value into a value of
+// type t.
+func csvDecoder(t reflect.Type, layout string) (func(string, reflect.Value) error, error) {
+	if t == timeType {
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return func(s string, v reflect.Value) error {
+			tm, err := time.Parse(layout, s)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(tm))
+			return nil
+		}, nil
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return func(s string, v reflect.Value) error {
+			return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem, err := csvDecoder(t.Elem(), layout)
+		if err != nil {
+			return nil, err
+		}
+		return func(s string, v reflect.Value) error {
+			if s == "" {
+				v.SetZero()
+				return nil
+			}
+			p := reflect.New(t.Elem())
+			if err := elem(s, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}, nil
+	case reflect.String:
+		return func(s string, v reflect.Value) error {
+			v.SetString(s)
+			return nil
+		}, nil
+	case reflect.Bool:
+		return func(s string, v reflect.Value) error {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return err
+			}
+			v.SetBool(b)
+			return nil
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(s string, v reflect.Value) error {
+			n, err := strconv.ParseInt(s, 10, t.Bits())
+			if err != nil {
+				return err
+			}
+			v.SetInt(n)
+			return nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(s string, v reflect.Value) error {
+			n, err := strconv.ParseUint(s, 10, t.Bits())
+			if err != nil {
+				return err
+			}
+			v.SetUint(n)
+			return nil
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(s string, v reflect.Value) error {
+			f, err := strconv.ParseFloat(s, t.Bits())
+			if err != nil {
+				return err
+			}
+			v.SetFloat(f)
+			return nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
@@ -0,0 +1,206 @@
+package itertools_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+type sale struct {
+	ID     int       `csv:"id"`
+	Amount float64   `csv:"amount"`
+	Paid   bool      `csv:"paid"`
+	Date   time.Time `csv:"date" layout:"2006-01-02"`
+	Refund *float64  `csv:"refund"`
+	Note   string
+	Ignore string `csv:"-"`
+}
+
+func TestFromCSVAs(t *testing.T) {
+	csvData := `id,amount,paid,date,refund,Note,Ignore
+1,9.99,true,2024-03-01,,first,x
+2,20,false,2024-03-02,5.5,,y`
+
+	iter, err := itertools.FromCSVAs[sale](csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	result := iter.Collect()
+	assert.NoError(t, iter.Err())
+
+	refund := 5.5
+	assert.Equal(t, []sale{
+		{ID: 1, Amount: 9.99, Paid: true, Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Note: "first"},
+		{ID: 2, Amount: 20, Date: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Refund: &refund},
+	}, result)
+}
+
+func TestFromCSVAs_ColumnOrderAndDefaults(t *testing.T) {
+	type point struct {
+		X  int8
+		Y  uint16
+		At time.Time
+		ID *int `csv:"id"`
+	}
+	csvData := `At,extra,Y,id,X
+2024-01-02T03:04:05Z,ignored,65535,7,-3`
+
+	iter, err := itertools.FromCSVAs[point](csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	result := iter.Collect()
+	id := 7
+	assert.Equal(t, []point{{X: -3, Y: 65535, At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ID: &id}}, result)
+}
+
+func TestFromCSVAs_ConversionError(t *testing.T) {
+	csvData := `id,amount,paid,date,refund
+1,1.5,true,2024-03-01,
+2,oops,true,2024-03-02,
+3,3,true,2024-03-03,`
+
+	iter, err := itertools.FromCSVAs[sale](csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	result := iter.Collect()
+	assert.Equal(t, 1, len(result))
+
+	var fieldErr *itertools.CSVFieldError
+	assert.True(t, errors.As(iter.Err(), &fieldErr))
+	assert.Equal(t, 1, fieldErr.Row)
+	assert.Equal(t, "amount", fieldErr.Column)
+	assert.ErrorIs(t, iter.Err(), strconv.ErrSyntax)
+}
+
+func TestFromCSVAs_ShortRow(t *testing.T) {
+	type pair struct {
+		A string `csv:"a"`
+		B int    `csv:"b"`
+	}
+	r := csv.NewReader(strings.NewReader("a,b\nx,1\ny\n"))
+	r.FieldsPerRecord = -1
+
+	var report itertools.CSVReport
+	iter, err := itertools.FromCSVAs[pair](r, itertools.WithCSVReport(&report))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []pair{{"x", 1}}, iter.Collect())
+	assert.Equal(t, 1, len(report.Rejections))
+	assert.Equal(t, 3, report.Rejections[0].Line)
+	assert.Equal(t, 0, report.Rejections[0].Column)
+	assert.Equal(t, []string{"y"}, report.Rejections[0].Record)
+
+	// The default policy reports the error instead of panicking
+	r = csv.NewReader(strings.NewReader("a,b\ny\n"))
+	r.FieldsPerRecord = -1
+	iter, err = itertools.FromCSVAs[pair](r)
+	assert.NoError(t, err)
+
+	assert.Empty(t, iter.Collect())
+	var fieldErr *itertools.CSVFieldError
+	assert.True(t, errors.As(iter.Err(), &fieldErr))
+	assert.Equal(t, "b", fieldErr.Column)
+}
+
+func TestFromCSVAs_Overflow(t *testing.T) {
+	type small struct {
+		N int8 `csv:"n"`
+	}
+	iter, err := itertools.FromCSVAs[small](csv.NewReader(strings.NewReader("n\n1\n300\n")))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []small{{1}}, iter.Collect())
+	assert.ErrorIs(t, iter.Err(), strconv.ErrRange)
+}
+
+func TestFromCSVAs_MissingColumn(t *testing.T) {
+	iter, err := itertools.FromCSVAs[sale](csv.NewReader(strings.NewReader("id,amount\n1,2\n")))
+
+	assert.Nil(t, iter)
+	assert.ErrorContains(t, err, `"paid"`)
+}
+
+func TestFromCSVAs_UnsupportedType(t *testing.T) {
+	type bad struct {
+		Tags []string `csv:"tags"`
+	}
+	_, err := itertools.FromCSVAs[bad](csv.NewReader(strings.NewReader("tags\na\n")))
+	assert.ErrorContains(t, err, "unsupported type")
+
+	_, err = itertools.FromCSVAs[int](csv.NewReader(strings.NewReader("n\n1\n")))
+	assert.Error(t, err)
+}
+
+func TestFromCSVAs_TextUnmarshaler(t *testing.T) {
+	type level struct {
+		Value levelName `csv:"level"`
+	}
+	iter, err := itertools.FromCSVAs[level](csv.NewReader(strings.NewReader("level\nwarn\nbogus\n")))
+	assert.NoError(t, err)
+
+	result := iter.Collect()
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, levelName("WARN"), result[0].Value)
+	assert.ErrorContains(t, iter.Err(), "unknown level")
+}
+
+type levelName string
+
+func (l *levelName) UnmarshalText(text []byte) error {
+	switch s := strings.ToUpper(string(text)); s {
+	case "INFO", "WARN", "ERROR":
+		*l = levelName(s)
+		return nil
+	}
+	return errors.New("unknown level")
+}
+
+func TestFromCSVAsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter, err := itertools.FromCSVAsWithContext[sale](ctx, csv.NewReader(strings.NewReader(
+		"id,amount,paid,date,refund\n1,1,true,2024-03-01,\n")))
+	assert.NoError(t, err)
+
+	assert.Empty(t, iter.Collect())
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestFromCSVAs_CollectPolicy(t *testing.T) {
+	type person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	csvData := `name,age
+Alice,30
+Bob,unknown
+Carol,41,extra
+Dave,28`
+
+	var report itertools.CSVReport
+	iter, err := itertools.FromCSVAs[person](csv.NewReader(strings.NewReader(csvData)), itertools.WithCSVReport(&report))
+	assert.NoError(t, err)
+
+	result := iter.Collect()
+	assert.Equal(t, []person{{"Alice", 30}, {"Dave", 28}}, result)
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, 2, len(report.Rejections))
+
+	conversion := report.Rejections[0]
+	assert.Equal(t, 3, conversion.Line)
+	assert.Equal(t, 5, conversion.Column)
+	assert.Equal(t, []string{"Bob", "unknown"}, conversion.Record)
+
+	var fieldErr *itertools.CSVFieldError
+	assert.True(t, errors.As(conversion.Err, &fieldErr))
+	assert.Equal(t, "age", fieldErr.Column)
+
+	assert.ErrorIs(t, report.Rejections[1].Err, csv.ErrFieldCount)
+}
@@ -0,0 +1,368 @@
+package itertools
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells EventTimeWindows the current time and wakes it up when
+// windows are due to close. SystemClock is the default; tests can inject a
+// clock that is advanced by hand.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock returns a Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// WindowAssigner decides which event-time windows an element belongs to.
+// Create one with TumblingWindows, SlidingWindows or SessionWindows.
+type WindowAssigner struct {
+	size  time.Duration
+	slide time.Duration
+	gap   time.Duration
+}
+
+// TumblingWindows assigns every element to exactly one window of the given
+// size. Windows are aligned to multiples of size since the zero time, so
+// one-minute windows start on the minute.
+func TumblingWindows(size time.Duration) WindowAssigner {
+	if size <= 0 {
+		panic("itertools: window size must be positive")
+	}
+	return WindowAssigner{size: size, slide: size}
+}
+
+// SlidingWindows assigns every element to the overlapping windows of the
+// given size that start every slide, such as five-minute windows that
+// start every minute. Windows are aligned to multiples of slide since the
+// zero time.
+func SlidingWindows(size, slide time.Duration) WindowAssigner {
+	if size <= 0 || slide <= 0 {
+		panic("itertools: window size and slide must be positive")
+	}
+	return WindowAssigner{size: size, slide: slide}
+}
+
+// SessionWindows groups elements into sessions of activity separated by
+// gaps of at least gap. A session ends gap after its last element, and two
+// sessions are merged when an element falls within gap of both.
+func SessionWindows(gap time.Duration) WindowAssigner {
+	if gap <= 0 {
+		panic("itertools: session gap must be positive")
+	}
+	return WindowAssigner{gap: gap}
+}
+
+// spans returns the bounds of the fixed windows that contain t.
+func (a WindowAssigner) spans(t time.Time) [][2]time.Time {
+	var spans [][2]time.Time
+	for start := t.Truncate(a.slide); start.Add(a.size).After(t); start = start.Add(-a.slide) {
+		spans = append(spans, [2]time.Time{start, start.Add(a.size)})
+	}
+	return spans
+}
+
+// LatePolicy selects what EventTimeWindows does with an element that
+// arrives after its window has closed.
+type LatePolicy int
+
+const (
+	// LateDrop discards late elements.
+	LateDrop LatePolicy = iota
+	// LateSideOutput passes late elements to the function given to
+	// WithLateSideOutput.
+	LateSideOutput
+	// LateUpdate yields every late element at once in a window of its own,
+	// with the bounds of the window it missed and Late set, so that it can be
+	// applied as a correction to the result already emitted.
+	LateUpdate
+)
+
+// EventWindow is a window of elements yielded by EventTimeWindows. It
+// covers the event times from Start up to but not including End.
+type EventWindow[V any] struct {
+	Start  time.Time
+	End    time.Time
+	Values []V
+	// Late is true for a window yielded by LateUpdate, holding an element
+	// that arrived after the window was closed.
+	Late bool
+}
+
+// EventTimeOption configures EventTimeWindows for elements of type V.
+type EventTimeOption[V any] func(*eventTimeConfig[V])
+
+type eventTimeConfig[V any] struct {
+	clock      Clock
+	lateness   time.Duration
+	policy     LatePolicy
+	sideOutput func(V)
+}
+
+// WithClock sets the clock that drives the watermark. The default is
+// SystemClock.
+//
+// Example:
+//
+//	opt := itertools.WithClock[Metric](clock)
+func WithClock[V any](c Clock) EventTimeOption[V] {
+	return func(cfg *eventTimeConfig[V]) {
+		cfg.clock = c
+	}
+}
+
+// WithAllowedLateness sets how long EventTimeWindows waits for stragglers
+// after the end of a window before closing it. The default is zero.
+func WithAllowedLateness[V any](d time.Duration) EventTimeOption[V] {
+	return func(cfg *eventTimeConfig[V]) {
+		cfg.lateness = max(d, 0)
+	}
+}
+
+// WithLatePolicy sets what happens to elements that arrive after their
+// window has closed. The default is LateDrop.
+func WithLatePolicy[V any](p LatePolicy) EventTimeOption[V] {
+	return func(cfg *eventTimeConfig[V]) {
+		cfg.policy = p
+	}
+}
+
+// WithLateSideOutput passes elements that arrive after their window has
+// closed to fn, and sets the LateSideOutput policy. fn runs on the
+// goroutine consuming the windows.
+//
+// Example:
+//
+//	opt := itertools.WithLateSideOutput(func(m Metric) {
+//	    lateCounter.Inc()
+//	})
+func WithLateSideOutput[V any](fn func(V)) EventTimeOption[V] {
+	return func(cfg *eventTimeConfig[V]) {
+		cfg.policy = LateSideOutput
+		cfg.sideOutput = fn
+	}
+}
+
+// openWindow is a window that has not been yielded yet.
+type openWindow[V any] struct {
+	start  time.Time
+	end    time.Time
+	values []V
+}
+
+// EventTimeWindows groups the elements of the iterator into windows by the
+// event time returned by timestamp, rather than by arrival order. It suits
+// unbounded streams such as FromChannelWithContext.
+//
+// The watermark is the clock's current time minus the allowed lateness. A
+// window is yielded once the watermark passes its end, so elements may
+// arrive out of order by up to the allowed lateness. Windows close on time
+// even while the input is idle. An element whose window has already closed
+// is late and is handled by the late policy. When the input ends, the
+// windows still open are yielded at once; when ctx is cancelled, iteration
+// stops and Err returns the context's error.
+//
+// Windows are yielded in order of their end time. Only windows with at least
+// one element are yielded.
+//
+// The input is read on a separate goroutine. When the consumer stops early,
+// iteration returns at once and that goroutine stops at its next element.
+//
+// Example:
+//
+//	ch := make(chan Metric)
+//	metrics := itertools.FromChannelWithContext(ctx, ch)
+//	buckets := itertools.EventTimeWindows(ctx, metrics,
+//	    func(m Metric) time.Time { return m.At },
+//	    itertools.TumblingWindows(time.Minute),
+//	    itertools.WithAllowedLateness[Metric](10*time.Second),
+//	    itertools.WithLatePolicy[Metric](itertools.LateUpdate))
+//	for w := range buckets.Seq() {
+//	    store(w.Start, w.Late, len(w.Values))
+//	}
+func EventTimeWindows[V any](ctx context.Context, it *Iterator[V], timestamp func(V) time.Time, assigner WindowAssigner, opts ...EventTimeOption[V]) *Iterator[EventWindow[V]] {
+	cfg := eventTimeConfig[V]{clock: SystemClock()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// The input is read on a separate goroutine, so its error is handed
+	// over under a lock.
+	var mu sync.Mutex
+	var srcErr error
+	var err error
+
+	return &Iterator[EventWindow[V]]{
+		seq: func(yield func(EventWindow[V]) bool) {
+			err = nil
+			pumpCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			elems := make(chan V)
+			go func() {
+				defer close(elems)
+				it.seq(func(v V) bool {
+					select {
+					case elems <- v:
+						return true
+					case <-pumpCtx.Done():
+						return false
+					}
+				})
+				mu.Lock()
+				srcErr = it.Err()
+				mu.Unlock()
+			}()
+
+			// open is kept sorted by end, then start
+			var open []*openWindow[V]
+			insert := func(w *openWindow[V]) {
+				i, _ := slices.BinarySearchFunc(open, w, func(a, b *openWindow[V]) int {
+					if c := a.end.Compare(b.end); c != 0 {
+						return c
+					}
+					return a.start.Compare(b.start)
+				})
+				open = slices.Insert(open, i, w)
+			}
+			emit := func(w *openWindow[V], late bool) bool {
+				return yield(EventWindow[V]{Start: w.start, End: w.end, Values: w.values, Late: late})
+			}
+
+			// add assigns v to its windows and reports false if yield asked to
+			// stop.
+			add := func(v V, watermark time.Time) bool {
+				t := timestamp(v)
+				var missed []*openWindow[V]
+				if assigner.gap > 0 {
+					session := &openWindow[V]{start: t, end: t.Add(assigner.gap)}
+					if !session.end.After(watermark) {
+						session.values = []V{v}
+						missed = append(missed, session)
+					} else {
+						// Absorb every open session that overlaps the new one
+						open = slices.DeleteFunc(open, func(w *openWindow[V]) bool {
+							if !w.start.Before(session.end) || !session.start.Before(w.end) {
+								return false
+							}
+							if w.start.Before(session.start) {
+								session.start = w.start
+							}
+							if w.end.After(session.end) {
+								session.end = w.end
+							}
+							session.values = append(session.values, w.values...)
+							return true
+						})
+						session.values = append(session.values, v)
+						insert(session)
+					}
+				} else {
+				spans:
+					for _, span := range assigner.spans(t) {
+						if !span[1].After(watermark) {
+							missed = append(missed, &openWindow[V]{start: span[0], end: span[1], values: []V{v}})
+							continue
+						}
+						for _, w := range open {
+							if w.start.Equal(span[0]) && w.end.Equal(span[1]) {
+								w.values = append(w.values, v)
+								continue spans
+							}
+						}
+						insert(&openWindow[V]{start: span[0], end: span[1], values: []V{v}})
+					}
+				}
+
+				if len(missed) == 0 {
+					return true
+				}
+				switch cfg.policy {
+				case LateSideOutput:
+					if cfg.sideOutput != nil {
+						cfg.sideOutput(v)
+					}
+				case LateUpdate:
+					for _, w := range missed {
+						if !emit(w, true) {
+							return false
+						}
+					}
+				}
+				return true
+			}
+
+			// fire yields the windows that end at or before the watermark
+			fire := func(watermark time.Time) bool {
+				for len(open) > 0 && !open[0].end.After(watermark) {
+					w := open[0]
+					open = open[1:]
+					if !emit(w, false) {
+						return false
+					}
+				}
+				return true
+			}
+
+			// wake fires when the earliest open window is due, and is only
+			// re-armed when that deadline changes
+			var wake <-chan time.Time
+			var deadline time.Time
+			for {
+				if len(open) == 0 {
+					wake = nil
+				} else if d := open[0].end.Add(cfg.lateness); wake == nil || !d.Equal(deadline) {
+					deadline = d
+					wake = cfg.clock.After(d.Sub(cfg.clock.Now()))
+				}
+				select {
+				case <-ctx.Done():
+					err = ctx.Err()
+					return
+				case <-wake:
+					wake = nil
+				case v, ok := <-elems:
+					if !ok {
+						for _, w := range open {
+							if !emit(w, false) {
+								return
+							}
+						}
+						return
+					}
+					if !add(v, cfg.clock.Now().Add(-cfg.lateness)) {
+						return
+					}
+				}
+				if !fire(cfg.clock.Now().Add(-cfg.lateness)) {
+					return
+				}
+			}
+		},
+		err: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				return err
+			}
+			return srcErr
+		},
+		closer: it.Close,
+	}
+}
@@ -0,0 +1,289 @@
+package itertools_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+type reading struct {
+	At    time.Time
+	Value int
+}
+
+func readingTime(r reading) time.Time { return r.At }
+
+var noon = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// at returns a reading taken the given number of seconds after noon.
+func at(seconds, value int) reading {
+	return reading{At: noon.Add(time.Duration(seconds) * time.Second), Value: value}
+}
+
+// fakeClock is a Clock that only moves when advanced. Every call to After
+// is signalled on armed, so tests can wait for the windowing loop to block.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+	armed  chan struct{}
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, armed: make(chan struct{}, 100)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.timers = append(c.timers, fakeTimer{deadline: c.now.Add(d), ch: ch})
+	}
+	c.armed <- struct{}{}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+func windowValues(windows []itertools.EventWindow[reading]) [][]int {
+	var values [][]int
+	for _, w := range windows {
+		var vs []int
+		for _, r := range w.Values {
+			vs = append(vs, r.Value)
+		}
+		values = append(values, vs)
+	}
+	return values
+}
+
+func TestEventTimeWindows_Tumbling(t *testing.T) {
+	clock := newFakeClock(noon)
+	readings := itertools.ToIter([]reading{at(5, 1), at(70, 2), at(30, 3), at(130, 4), at(61, 5)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Minute), itertools.WithClock[reading](clock)).Collect()
+
+	assert.Equal(t, [][]int{{1, 3}, {2, 5}, {4}}, windowValues(windows))
+	assert.Equal(t, noon, windows[0].Start)
+	assert.Equal(t, noon.Add(time.Minute), windows[0].End)
+	assert.Equal(t, noon.Add(2*time.Minute), windows[2].Start)
+}
+
+func TestEventTimeWindows_Sliding(t *testing.T) {
+	clock := newFakeClock(noon)
+	readings := itertools.ToIter([]reading{at(10, 1), at(40, 2), at(70, 3)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.SlidingWindows(time.Minute, 30*time.Second), itertools.WithClock[reading](clock)).Collect()
+
+	// Windows start every 30s: [-30s, 30s), [0, 60s), [30s, 90s), [60s, 120s)
+	assert.Equal(t, [][]int{{1}, {1, 2}, {2, 3}, {3}}, windowValues(windows))
+	assert.Equal(t, noon.Add(-30*time.Second), windows[0].Start)
+	assert.Equal(t, noon.Add(30*time.Second), windows[2].Start)
+}
+
+func TestEventTimeWindows_Session(t *testing.T) {
+	clock := newFakeClock(noon)
+	// 98 bridges the sessions started by 80 and 115
+	readings := itertools.ToIter([]reading{at(0, 1), at(10, 2), at(80, 3), at(115, 4), at(98, 5), at(300, 6)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.SessionWindows(20*time.Second), itertools.WithClock[reading](clock)).Collect()
+
+	assert.Equal(t, 3, len(windows))
+	assert.Equal(t, []int{1, 2}, windowValues(windows)[0])
+	assert.Equal(t, []int{3, 4, 5}, windowValues(windows)[1])
+	assert.Equal(t, noon.Add(80*time.Second), windows[1].Start)
+	assert.Equal(t, noon.Add(135*time.Second), windows[1].End)
+	assert.Equal(t, []int{6}, windowValues(windows)[2])
+}
+
+func TestEventTimeWindows_ClockClosesWindows(t *testing.T) {
+	clock := newFakeClock(noon)
+	ch := make(chan reading)
+	defer close(ch)
+	windows := itertools.EventTimeWindows(context.Background(), itertools.FromChannel(ch), readingTime,
+		itertools.TumblingWindows(time.Minute),
+		itertools.WithClock[reading](clock),
+		itertools.WithAllowedLateness[reading](10*time.Second))
+
+	results := make(chan itertools.EventWindow[reading])
+	go func() {
+		for w := range windows.Seq() {
+			results <- w
+		}
+	}()
+
+	ch <- at(5, 1)
+	<-clock.armed
+	clock.Advance(65 * time.Second)
+	select {
+	case w := <-results:
+		t.Fatalf("window closed before the allowed lateness: %v", w)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(5 * time.Second)
+	select {
+	case w := <-results:
+		assert.Equal(t, noon, w.Start)
+		assert.Equal(t, 1, len(w.Values))
+		assert.False(t, w.Late)
+	case <-time.After(time.Second):
+		t.Fatal("window was not closed by the clock")
+	}
+}
+
+func TestEventTimeWindows_AllowedLateness(t *testing.T) {
+	// The window [12:00, 12:01) is still open until 12:01:10
+	clock := newFakeClock(noon.Add(65 * time.Second))
+	readings := itertools.ToIter([]reading{at(50, 1), at(70, 2)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Minute),
+		itertools.WithClock[reading](clock),
+		itertools.WithAllowedLateness[reading](10*time.Second)).Collect()
+
+	assert.Equal(t, [][]int{{1}, {2}}, windowValues(windows))
+}
+
+func TestEventTimeWindows_LateDrop(t *testing.T) {
+	clock := newFakeClock(noon.Add(90 * time.Second))
+	readings := itertools.ToIter([]reading{at(30, 1), at(80, 2)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Minute), itertools.WithClock[reading](clock)).Collect()
+
+	assert.Equal(t, [][]int{{2}}, windowValues(windows))
+}
+
+func TestEventTimeWindows_LateSideOutput(t *testing.T) {
+	clock := newFakeClock(noon.Add(90 * time.Second))
+	readings := itertools.ToIter([]reading{at(30, 1), at(80, 2), at(10, 3)})
+
+	var late []int
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Minute),
+		itertools.WithClock[reading](clock),
+		itertools.WithLateSideOutput(func(r reading) { late = append(late, r.Value) }))
+
+	assert.Equal(t, [][]int{{2}}, windowValues(windows.Collect()))
+	assert.Equal(t, []int{1, 3}, late)
+	assert.NoError(t, windows.Err())
+}
+
+func TestEventTimeWindows_LateUpdate(t *testing.T) {
+	clock := newFakeClock(noon.Add(90 * time.Second))
+	readings := itertools.ToIter([]reading{at(80, 1), at(30, 2)})
+
+	windows := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Minute),
+		itertools.WithClock[reading](clock),
+		itertools.WithLatePolicy[reading](itertools.LateUpdate)).Collect()
+
+	assert.Equal(t, 2, len(windows))
+	assert.True(t, windows[0].Late)
+	assert.Equal(t, noon, windows[0].Start)
+	assert.Equal(t, []int{2}, windowValues(windows)[0])
+	assert.False(t, windows[1].Late)
+	assert.Equal(t, []int{1}, windowValues(windows)[1])
+}
+
+func TestEventTimeWindows_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan reading)
+	defer close(ch)
+	windows := itertools.EventTimeWindows(ctx, itertools.FromChannelWithContext(ctx, ch), readingTime,
+		itertools.TumblingWindows(time.Minute), itertools.WithClock[reading](newFakeClock(noon)))
+
+	go func() {
+		ch <- at(0, 1)
+		cancel()
+	}()
+
+	assert.Empty(t, windows.Collect())
+	assert.ErrorIs(t, windows.Err(), context.Canceled)
+}
+
+func TestEventTimeWindows_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken})
+	windows := itertools.EventTimeWindows(context.Background(), lines,
+		func(string) time.Time { return noon },
+		itertools.TumblingWindows(time.Minute), itertools.WithClock[string](newFakeClock(noon)))
+
+	collected := windows.Collect()
+
+	assert.Equal(t, 1, len(collected))
+	assert.Equal(t, []string{"a", "b"}, collected[0].Values)
+	assert.ErrorIs(t, windows.Err(), errBroken)
+}
+
+func TestEventTimeWindows_EarlyTermination(t *testing.T) {
+	readings := itertools.MapTo(itertools.Range(0, 1000), func(i int) reading { return at(i, i) })
+
+	first := itertools.EventTimeWindows(context.Background(), readings, readingTime,
+		itertools.TumblingWindows(time.Second), itertools.WithClock[reading](newFakeClock(noon))).Take(2).Collect()
+
+	assert.Equal(t, [][]int{{0}, {1}}, windowValues(first))
+}
+
+func TestEventTimeWindows_EarlyStopIdleInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan reading, 1)
+	ch <- at(0, 1)
+	defer close(ch)
+
+	windows := itertools.EventTimeWindows(ctx, itertools.FromChannelWithContext(ctx, ch), readingTime,
+		itertools.SessionWindows(time.Minute),
+		// Every element is late, so it is yielded at once
+		itertools.WithClock[reading](newFakeClock(noon.Add(2*time.Minute))),
+		itertools.WithLatePolicy[reading](itertools.LateUpdate))
+	returned := returnsWithin(time.Second, func() {
+		for w := range windows.Seq() {
+			assert.Equal(t, [][]int{{1}}, windowValues([]itertools.EventWindow[reading]{w}))
+			break
+		}
+	})
+	assert.True(t, returned, "EventTimeWindows did not return while its input was idle")
+}
+
+func TestWindowAssigners_InvalidDuration(t *testing.T) {
+	assert.Panics(t, func() { itertools.TumblingWindows(0) })
+	assert.Panics(t, func() { itertools.SlidingWindows(time.Minute, -time.Second) })
+	assert.Panics(t, func() { itertools.SessionWindows(0) })
+}
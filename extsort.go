@@ -0,0 +1,256 @@
+package itertools
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+)
+
+// Codec serializes elements of type V to and from the temporary files used
+// by ExternalSort. GobCodec and JSONCodec cover most types.
+type Codec[V any] interface {
+	NewEncoder(w io.Writer) Encoder[V]
+	NewDecoder(r io.Reader) Decoder[V]
+}
+
+// Encoder writes elements to a stream.
+type Encoder[V any] interface {
+	Encode(v V) error
+}
+
+// Decoder reads elements from a stream. Decode returns io.EOF when the
+// stream is exhausted.
+type Decoder[V any] interface {
+	Decode() (V, error)
+}
+
+// GobCodec returns a Codec that uses encoding/gob. It is compact and fast,
+// and handles any type gob can encode.
+func GobCodec[V any]() Codec[V] {
+	return gobCodec[V]{}
+}
+
+type gobCodec[V any] struct{}
+
+func (gobCodec[V]) NewEncoder(w io.Writer) Encoder[V] {
+	return streamEncoder[V]{gob.NewEncoder(w)}
+}
+
+func (gobCodec[V]) NewDecoder(r io.Reader) Decoder[V] {
+	return streamDecoder[V]{gob.NewDecoder(r)}
+}
+
+// JSONCodec returns a Codec that uses encoding/json, one value per line.
+func JSONCodec[V any]() Codec[V] {
+	return jsonCodec[V]{}
+}
+
+type jsonCodec[V any] struct{}
+
+func (jsonCodec[V]) NewEncoder(w io.Writer) Encoder[V] {
+	return streamEncoder[V]{json.NewEncoder(w)}
+}
+
+func (jsonCodec[V]) NewDecoder(r io.Reader) Decoder[V] {
+	return streamDecoder[V]{json.NewDecoder(r)}
+}
+
+// streamEncoder adapts an encoder of the gob or json kind to Encoder.
+type streamEncoder[V any] struct {
+	enc interface{ Encode(any) error }
+}
+
+func (e streamEncoder[V]) Encode(v V) error {
+	return e.enc.Encode(v)
+}
+
+// streamDecoder adapts a decoder of the gob or json kind to Decoder.
+type streamDecoder[V any] struct {
+	dec interface{ Decode(any) error }
+}
+
+func (d streamDecoder[V]) Decode() (V, error) {
+	var v V
+	err := d.dec.Decode(&v)
+	return v, err
+}
+
+// ExternalSortOption configures ExternalSort for elements of type V.
+type ExternalSortOption[V any] func(*externalSortConfig[V])
+
+type externalSortConfig[V any] struct {
+	maxElements int
+	maxBytes    int
+	size        func(V) int
+	dir         string
+}
+
+// WithMaxElements sets the number of elements ExternalSort holds in memory
+// before it spills a sorted run to disk. The default is 1,000,000.
+//
+// Example:
+//
+//	opt := itertools.WithMaxElements[[]string](500_000)
+func WithMaxElements[V any](n int) ExternalSortOption[V] {
+	return func(c *externalSortConfig[V]) {
+		c.maxElements = max(n, 1)
+	}
+}
+
+// WithMaxBytes makes ExternalSort spill a sorted run to disk once the
+// elements held in memory add up to n bytes, as measured by size. It can be
+// combined with WithMaxElements; a run is spilled when either limit is
+// reached.
+//
+// Example:
+//
+//	opt := itertools.WithMaxBytes(512<<20, func(row []string) int {
+//	    n := 24
+//	    for _, f := range row {
+//	        n += 16 + len(f)
+//	    }
+//	    return n
+//	})
+func WithMaxBytes[V any](n int, size func(V) int) ExternalSortOption[V] {
+	return func(c *externalSortConfig[V]) {
+		c.maxBytes = n
+		c.size = size
+	}
+}
+
+// WithTempDir sets the directory for the temporary run files. The default is
+// os.TempDir.
+func WithTempDir[V any](dir string) ExternalSortOption[V] {
+	return func(c *externalSortConfig[V]) {
+		c.dir = dir
+	}
+}
+
+// ExternalSort returns a new iterator with elements sorted according to the
+// less function, for inputs that do not fit in memory. Elements are read in
+// runs that fit the memory budget; each run is sorted and written to a
+// temporary file with codec. The runs are then merged lazily, holding one
+// element per run in memory. The sort is stable.
+//
+// The input is read and spilled when iteration starts. The temporary files
+// are removed when iteration ends, including on early termination. Errors
+// from the input, the codec or the file system are reported by Err.
+//
+// Example:
+//
+//	rows := itertools.FromCSV(csv.NewReader(file))
+//	sorted := itertools.ExternalSort(rows,
+//	    func(a, b []string) bool { return a[0] < b[0] },
+//	    itertools.GobCodec[[]string](),
+//	    itertools.WithMaxElements[[]string](500_000),
+//	    itertools.WithTempDir[[]string]("/mnt/scratch"))
+//	err := itertools.ToCSV(sorted, csv.NewWriter(out), nil)
+func ExternalSort[V any](it *Iterator[V], less func(a, b V) bool, codec Codec[V], opts ...ExternalSortOption[V]) *Iterator[V] {
+	cfg := externalSortConfig[V]{maxElements: 1_000_000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			sortRun := func(run []V) {
+				slices.SortStableFunc(run, func(a, b V) int {
+					switch {
+					case less(a, b):
+						return -1
+					case less(b, a):
+						return 1
+					}
+					return 0
+				})
+			}
+
+			var files []*os.File
+			defer func() {
+				for _, f := range files {
+					f.Close()
+					os.Remove(f.Name())
+				}
+			}()
+
+			var buf []V
+			bytes := 0
+			it.seq(func(v V) bool {
+				buf = append(buf, v)
+				if cfg.size != nil {
+					bytes += cfg.size(v)
+				}
+				if len(buf) < cfg.maxElements && (cfg.size == nil || bytes < cfg.maxBytes) {
+					return true
+				}
+				sortRun(buf)
+				var f *os.File
+				if f, err = os.CreateTemp(cfg.dir, "itertools-sort-*"); err != nil {
+					return false
+				}
+				files = append(files, f)
+				if err = writeRun(f, buf, codec); err != nil {
+					return false
+				}
+				clear(buf)
+				buf, bytes = buf[:0], 0
+				return true
+			})
+			if err != nil || it.Err() != nil {
+				return
+			}
+
+			// The last run stays in memory and is merged with the spilled ones
+			sortRun(buf)
+			sources := make([]func() (V, bool), 0, len(files)+1)
+			for _, f := range files {
+				if _, err = f.Seek(0, io.SeekStart); err != nil {
+					return
+				}
+				dec := codec.NewDecoder(bufio.NewReader(f))
+				sources = append(sources, func() (V, bool) {
+					v, decodeErr := dec.Decode()
+					if decodeErr != nil {
+						if decodeErr != io.EOF {
+							err = decodeErr
+						}
+						return v, false
+					}
+					return v, true
+				})
+			}
+			i := 0
+			sources = append(sources, func() (V, bool) {
+				if i == len(buf) {
+					var zero V
+					return zero, false
+				}
+				i++
+				return buf[i-1], true
+			})
+
+			mergeSorted(sources, less, func(v V) bool {
+				return err == nil && yield(v)
+			})
+		},
+		err:    firstErr(func() error { return err }, it.Err),
+		closer: it.Close,
+	}
+}
+
+// writeRun encodes the elements of run to f.
+func writeRun[V any](f *os.File, run []V, codec Codec[V]) error {
+	w := bufio.NewWriter(f)
+	enc := codec.NewEncoder(w)
+	for _, v := range run {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,145 @@
+package itertools_test
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func shuffled(n int) []int {
+	xs := itertools.Range(0, n).Collect()
+	rand.New(rand.NewSource(1)).Shuffle(n, func(i, j int) { xs[i], xs[j] = xs[j], xs[i] })
+	return xs
+}
+
+func tempFiles(t *testing.T, dir string) int {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	return len(entries)
+}
+
+func TestExternalSort(t *testing.T) {
+	dir := t.TempDir()
+	iter := itertools.ExternalSort(itertools.ToIter(shuffled(1000)), intLess, itertools.GobCodec[int](),
+		itertools.WithMaxElements[int](64), itertools.WithTempDir[int](dir))
+
+	result := iter.Collect()
+
+	assert.Equal(t, itertools.Range(0, 1000).Collect(), result)
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, 0, tempFiles(t, dir))
+}
+
+func TestExternalSort_InMemory(t *testing.T) {
+	dir := t.TempDir()
+	iter := itertools.ExternalSort(itertools.ToIter([]int{3, 1, 2}), intLess, itertools.GobCodec[int](),
+		itertools.WithTempDir[int](dir))
+
+	assert.Equal(t, []int{1, 2, 3}, iter.Collect())
+}
+
+func TestExternalSort_Stable(t *testing.T) {
+	type rec struct {
+		Key int
+		Seq int
+	}
+	var recs []rec
+	for i, k := range shuffled(300) {
+		recs = append(recs, rec{Key: k % 7, Seq: i})
+	}
+
+	result := itertools.ExternalSort(itertools.ToIter(recs), func(a, b rec) bool { return a.Key < b.Key },
+		itertools.JSONCodec[rec](), itertools.WithMaxElements[rec](20), itertools.WithTempDir[rec](t.TempDir())).Collect()
+
+	expected := slices.Clone(recs)
+	slices.SortStableFunc(expected, func(a, b rec) int { return a.Key - b.Key })
+	assert.Equal(t, expected, result)
+}
+
+func TestExternalSort_MaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	words := []string{"pear", "fig", "apple", "kiwi", "banana", "cherry", "date", "grape"}
+
+	measured := 0
+	iter := itertools.ExternalSort(itertools.ToIter(words), func(a, b string) bool { return a < b },
+		itertools.GobCodec[string](),
+		itertools.WithMaxBytes(10, func(s string) int { measured++; return len(s) }),
+		itertools.WithTempDir[string](dir))
+
+	assert.Equal(t, []string{"apple", "banana", "cherry", "date", "fig", "grape", "kiwi", "pear"}, iter.Collect())
+	assert.Equal(t, len(words), measured)
+	assert.NoError(t, iter.Err())
+}
+
+func TestExternalSort_EarlyTerminationRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	iter := itertools.ExternalSort(itertools.ToIter(shuffled(500)), intLess, itertools.GobCodec[int](),
+		itertools.WithMaxElements[int](50), itertools.WithTempDir[int](dir))
+
+	assert.Equal(t, []int{0, 1, 2}, iter.Take(3).Collect())
+	assert.Equal(t, 0, tempFiles(t, dir))
+}
+
+func TestExternalSort_InputErr(t *testing.T) {
+	dir := t.TempDir()
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "c\nb\na\n", err: errBroken})
+
+	iter := itertools.ExternalSort(lines, func(a, b string) bool { return a < b }, itertools.GobCodec[string](),
+		itertools.WithMaxElements[string](1), itertools.WithTempDir[string](dir))
+
+	assert.Empty(t, iter.Collect())
+	assert.ErrorIs(t, iter.Err(), errBroken)
+	assert.Equal(t, 0, tempFiles(t, dir))
+}
+
+// failingCodec fails to encode values above a limit.
+type failingCodec struct {
+	limit int
+}
+
+func (c failingCodec) NewEncoder(w io.Writer) itertools.Encoder[int] {
+	return failingEncoder{limit: c.limit, enc: itertools.GobCodec[int]().NewEncoder(w)}
+}
+
+func (c failingCodec) NewDecoder(r io.Reader) itertools.Decoder[int] {
+	return itertools.GobCodec[int]().NewDecoder(r)
+}
+
+type failingEncoder struct {
+	limit int
+	enc   itertools.Encoder[int]
+}
+
+func (e failingEncoder) Encode(v int) error {
+	if v > e.limit {
+		return errors.New("value too large")
+	}
+	return e.enc.Encode(v)
+}
+
+func TestExternalSort_CodecErr(t *testing.T) {
+	dir := t.TempDir()
+	iter := itertools.ExternalSort(itertools.ToIter(shuffled(100)), intLess, failingCodec{limit: 90},
+		itertools.WithMaxElements[int](10), itertools.WithTempDir[int](dir))
+
+	assert.Empty(t, iter.Collect())
+	assert.ErrorContains(t, iter.Err(), "value too large")
+	assert.Equal(t, 0, tempFiles(t, dir))
+}
+
+func TestExternalSort_BadTempDir(t *testing.T) {
+	iter := itertools.ExternalSort(itertools.Range(0, 10), intLess, itertools.GobCodec[int](),
+		itertools.WithMaxElements[int](2), itertools.WithTempDir[int]("/nonexistent/itertools"))
+
+	assert.Empty(t, iter.Collect())
+	assert.Error(t, iter.Err())
+}
@@ -23,6 +23,14 @@ import (
 // elements are consumed. This allows efficient processing of large or
 // infinite sequences.
 //
+// Sources that can fail, such as FromReader or FromCSV, stop iterating on the
+// first error and report it through Err. Every operator carries the error of
+// its inputs, so checking Err on the final iterator of a pipeline is enough.
+//
+// Close releases the pull coroutine started by Next and any resources held by
+// the underlying sources. It should be deferred when an iterator is consumed
+// with Next and may be abandoned before it is exhausted.
+//
 // Example:
 //
 //	iter := itertools.ToIter([]int{1, 2, 3, 4, 5})
@@ -32,9 +40,14 @@ import (
 //	    Collect()
 //	// result is []int{4, 16}
 type Iterator[V any] struct {
-	seq  iter.Seq[V]
-	curr *V
-	done bool
+	seq iter.Seq[V]
+	// err reports the error that stopped the underlying source, if any
+	err func() error
+	// closer releases resources held by the underlying sources, if any
+	closer func() error
+	closed bool
+	curr   *V
+	done   bool
 	// Pull-based iterator for Next/Current
 	pull func() (V, bool)
 	stop func()
@@ -61,6 +74,123 @@ func ToIter[V any](slice []V) *Iterator[V] {
 	}
 }
 
+// FromSeq creates an Iterator from a standard library iter.Seq.
+// This allows sequences produced by packages such as slices and maps
+// to be used with itertools operations.
+//
+// Example:
+//
+//	iter := itertools.FromSeq(slices.Values([]int{1, 2, 3}))
+//	doubled := iter.Map(func(x int) int { return x * 2 }).Collect()
+//	// doubled is []int{2, 4, 6}
+func FromSeq[V any](seq iter.Seq[V]) *Iterator[V] {
+	return &Iterator[V]{seq: seq}
+}
+
+// FromSeq2 creates an Iterator of pairs from a standard library iter.Seq2.
+// Each key/value pair is yielded as a struct with First and Second fields,
+// matching the element type produced by Zip.
+//
+// Example:
+//
+//	m := map[string]int{"a": 1}
+//	iter := itertools.FromSeq2(maps.All(m))
+//	pairs := iter.Collect()
+//	// pairs is [{First: "a", Second: 1}]
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) *Iterator[struct {
+	First  K
+	Second V
+}] {
+	return &Iterator[struct {
+		First  K
+		Second V
+	}]{
+		seq: func(yield func(struct {
+			First  K
+			Second V
+		}) bool,
+		) {
+			for k, v := range seq {
+				if !yield(struct {
+					First  K
+					Second V
+				}{k, v}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ToSeq2 converts an Iterator of pairs, such as one produced by Zip or
+// FromSeq2, into a standard library iter.Seq2. This allows a pipeline of
+// pairs to be passed to functions such as maps.Collect.
+//
+// Example:
+//
+//	keys := itertools.ToIter([]string{"a", "b"})
+//	values := itertools.ToIter([]int{1, 2})
+//	m := maps.Collect(itertools.ToSeq2(itertools.Zip(keys, values)))
+//	// m is map[string]int{"a": 1, "b": 2}
+func ToSeq2[K, V any](it *Iterator[struct {
+	First  K
+	Second V
+}],
+) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it.seq(func(p struct {
+			First  K
+			Second V
+		},
+		) bool {
+			return yield(p.First, p.Second)
+		})
+	}
+}
+
+// Seq returns the iterator as a standard library iter.Seq.
+// The result can be used with range-over-func loops or passed to
+// functions such as slices.Collect.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3})
+//	for v := range iter.Seq() {
+//	    fmt.Println(v)
+//	}
+func (it *Iterator[V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if !it.closed {
+			it.seq(yield)
+		}
+	}
+}
+
+// Seq2 returns the iterator as a standard library iter.Seq2 that yields
+// each element together with its 0-based index, like slices.All.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"a", "b", "c"})
+//	for i, v := range iter.Seq2() {
+//	    fmt.Println(i, v)
+//	}
+func (it *Iterator[V]) Seq2() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		if it.closed {
+			return
+		}
+		i := 0
+		it.seq(func(v V) bool {
+			if !yield(i, v) {
+				return false
+			}
+			i++
+			return true
+		})
+	}
+}
+
 // Next advances the iterator to the next element and returns true if successful.
 // It returns false when the iterator is exhausted.
 //
@@ -74,7 +204,7 @@ func ToIter[V any](slice []V) *Iterator[V] {
 //	    fmt.Println(iter.Current())
 //	}
 func (it *Iterator[V]) Next() bool {
-	if it.done {
+	if it.done || it.closed {
 		return false
 	}
 
@@ -119,6 +249,103 @@ func (it *Iterator[V]) Current() V {
 	return *it.curr
 }
 
+// Err returns the error that stopped iteration early, or nil if the
+// iterator was exhausted normally or stopped by the consumer.
+// Err should be checked after a terminal operation such as Collect, Each
+// or a Next loop, in the same way as bufio.Scanner.Err.
+//
+// Example:
+//
+//	iter := itertools.FromReader(file).Filter(isError)
+//	lines := iter.Collect()
+//	if err := iter.Err(); err != nil {
+//	    return err // lines is incomplete
+//	}
+func (it *Iterator[V]) Err() error {
+	if it.err == nil {
+		return nil
+	}
+	return it.err()
+}
+
+// Close stops the iterator and releases its resources: the pull coroutine
+// started by Next, if any, and the cleanup hooks of the underlying sources,
+// such as a hook added with OnClose. After Close, Next returns false and
+// Collect, Each, Seq and Seq2 no longer run the source.
+//
+// Close is safe to call more than once and on iterators that were never
+// started. It returns the first error reported by a cleanup hook.
+//
+// Example:
+//
+//	iter := itertools.FromReader(file).OnClose(file.Close)
+//	defer iter.Close()
+//	for iter.Next() {
+//	    if iter.Current() == "END" {
+//	        break
+//	    }
+//	}
+func (it *Iterator[V]) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	it.done = true
+	if it.stop != nil {
+		it.stop()
+	}
+	if it.closer != nil {
+		return it.closer()
+	}
+	return nil
+}
+
+// OnClose returns an iterator that yields the same elements and calls fn
+// when it is closed, after closing the original iterator.
+// This is useful for attaching the cleanup of a source that has no
+// io.Closer of its own, such as the file behind a csv.Reader, or the file
+// read by FromReader, which does not close it.
+//
+// Example:
+//
+//	file, _ := os.Open("data.csv")
+//	iter := itertools.FromCSV(csv.NewReader(file)).OnClose(file.Close)
+//	defer iter.Close()
+func (it *Iterator[V]) OnClose(fn func() error) *Iterator[V] {
+	return &Iterator[V]{
+		seq:    it.seq,
+		err:    it.Err,
+		closer: closeAll(it.Close, fn),
+	}
+}
+
+// closeAll returns a cleanup hook that calls every given hook and reports
+// the first error. It is used by operators with several inputs.
+func closeAll(closers ...func() error) func() error {
+	return func() error {
+		var first error
+		for _, c := range closers {
+			if err := c(); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+}
+
+// firstErr returns an error accessor that reports the first non-nil error
+// among the given accessors. It is used by operators with several inputs.
+func firstErr(errs ...func() error) func() error {
+	return func() error {
+		for _, err := range errs {
+			if e := err(); e != nil {
+				return e
+			}
+		}
+		return nil
+	}
+}
+
 // Collect consumes the iterator and returns all elements as a slice.
 // After calling Collect, the iterator is exhausted.
 //
@@ -132,6 +359,9 @@ func (it *Iterator[V]) Current() V {
 //	// evens is []int{2, 4}
 func (it *Iterator[V]) Collect() []V {
 	collect := make([]V, 0)
+	if it.closed {
+		return collect
+	}
 	it.seq(func(e V) bool {
 		collect = append(collect, e)
 		return true
@@ -151,6 +381,9 @@ func (it *Iterator[V]) Collect() []V {
 //	    fmt.Println(x)
 //	})
 func (it *Iterator[V]) Each(f func(V)) {
+	if it.closed {
+		return
+	}
 	it.seq(func(v V) bool {
 		f(v)
 		return true
@@ -172,7 +405,10 @@ func (it *Iterator[V]) Reverse() *Iterator[V] {
 	for i, j := 0, len(xs)-1; i < j; i, j = i+1, j-1 {
 		xs[i], xs[j] = xs[j], xs[i]
 	}
-	return ToIter(xs)
+	reversed := ToIter(xs)
+	reversed.err = it.Err
+	reversed.closer = it.Close
+	return reversed
 }
 
 // Filter returns a new iterator that only yields elements satisfying the predicate.
@@ -195,6 +431,8 @@ func (it *Iterator[V]) Filter(predicate func(V) bool) *Iterator[V] {
 				return true
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -214,6 +452,8 @@ func (it *Iterator[V]) Map(f func(V) V) *Iterator[V] {
 				return yield(f(v))
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -221,7 +461,8 @@ func (it *Iterator[V]) Map(f func(V) V) *Iterator[V] {
 // the first iterator followed by all elements from the second.
 //
 // Chain properly handles early termination - if iteration stops early,
-// the second iterator may not be consumed at all.
+// the second iterator may not be consumed at all. The second iterator is
+// also skipped when the first one stops with an error.
 //
 // Example:
 //
@@ -240,11 +481,13 @@ func (it *Iterator[V]) Chain(other *Iterator[V]) *Iterator[V] {
 				}
 				return true
 			})
-			if !shouldContinue {
+			if !shouldContinue || it.Err() != nil {
 				return
 			}
 			other.seq(yield)
 		},
+		err:    firstErr(it.Err, other.Err),
+		closer: closeAll(it.Close, other.Close),
 	}
 }
 
@@ -270,6 +513,8 @@ func (it *Iterator[V]) Take(n int) *Iterator[V] {
 				return false
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -293,6 +538,8 @@ func (it *Iterator[V]) Drop(n int) *Iterator[V] {
 				return yield(v)
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -314,6 +561,8 @@ func (it *Iterator[V]) TakeWhile(predicate func(V) bool) *Iterator[V] {
 				return false
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -340,6 +589,8 @@ func (it *Iterator[V]) DropWhile(predicate func(V) bool) *Iterator[V] {
 				return true
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -348,11 +599,15 @@ func (it *Iterator[V]) DropWhile(predicate func(V) bool) *Iterator[V] {
 //
 // For a safe alternative that doesn't panic, use FirstOr.
 //
+// First closes the iterator before returning, since the remaining
+// elements are abandoned.
+//
 // Example:
 //
 //	iter := itertools.ToIter([]int{1, 2, 3})
 //	first := iter.First() // Returns 1
 func (it *Iterator[V]) First() V {
+	defer it.Close()
 	it.Next()
 	return it.Current()
 }
@@ -377,6 +632,8 @@ func (it *Iterator[V]) Last() V {
 //
 // For a safe alternative that doesn't panic, use NthOr.
 //
+// Like First, Nth closes the iterator before returning.
+//
 // Example:
 //
 //	iter := itertools.ToIter([]int{10, 20, 30, 40})
@@ -388,6 +645,8 @@ func (it *Iterator[V]) Nth(n int) V {
 // FirstOr returns the first element of the iterator, or defaultValue if the iterator is empty.
 // This is a safe alternative to First that doesn't panic.
 //
+// Like First, FirstOr closes the iterator before returning.
+//
 // Example:
 //
 //	iter := itertools.ToIter([]int{})
@@ -396,6 +655,7 @@ func (it *Iterator[V]) Nth(n int) V {
 //	iter2 := itertools.ToIter([]int{1, 2, 3})
 //	first2 := iter2.FirstOr(999) // Returns 1
 func (it *Iterator[V]) FirstOr(defaultValue V) V {
+	defer it.Close()
 	var result V
 	found := false
 	it.seq(func(v V) bool {
@@ -438,12 +698,15 @@ func (it *Iterator[V]) LastOr(defaultValue V) V {
 // NthOr returns the nth element (0-indexed) of the iterator, or defaultValue if there aren't enough elements.
 // This is a safe alternative to Nth that doesn't panic.
 //
+// Like Nth, NthOr closes the iterator before returning.
+//
 // Example:
 //
 //	iter := itertools.ToIter([]int{10, 20, 30})
 //	third := iter.NthOr(2, 999)  // Returns 30
 //	fifth := iter.NthOr(4, 999)  // Returns 999 (not enough elements)
 func (it *Iterator[V]) NthOr(n int, defaultValue V) V {
+	defer it.Close()
 	var result V
 	found := false
 	i := 0
@@ -538,7 +801,10 @@ func (it *Iterator[V]) Sort(less func(a, b V) bool) *Iterator[V] {
 	sort.Slice(xs, func(i, j int) bool {
 		return less(xs[i], xs[j])
 	})
-	return ToIter(xs)
+	sorted := ToIter(xs)
+	sorted.err = it.Err
+	sorted.closer = it.Close
+	return sorted
 }
 
 // Min returns the minimum element according to the less function, along with true.
@@ -613,6 +879,10 @@ func (it *Iterator[V]) Partition(predicate func(V) bool) (matched *Iterator[V],
 
 	matched = ToIter(yes)
 	unmatched = ToIter(no)
+	matched.err = it.Err
+	matched.closer = it.Close
+	unmatched.err = it.Err
+	unmatched.closer = it.Close
 	return
 }
 
@@ -657,6 +927,8 @@ func (it *Iterator[V]) Unique(keyFunc func(V) any) *Iterator[V] {
 				return yield(v)
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -706,6 +978,8 @@ func (it *Iterator[V]) Cycle() *Iterator[V] {
 				}
 			}
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -769,10 +1043,13 @@ func (it *Iterator[V]) Intersection(other *Iterator[V], keyFunc func(V) any) *It
 		return true
 	})
 
-	return it.Filter(func(v V) bool {
+	intersection := it.Filter(func(v V) bool {
 		_, ok := seen[keyFunc(v)]
 		return ok
 	})
+	intersection.err = firstErr(it.Err, other.Err)
+	intersection.closer = closeAll(it.Close, other.Close)
+	return intersection
 }
 
 // Difference returns an iterator that yields elements present in this iterator
@@ -794,10 +1071,13 @@ func (it *Iterator[V]) Difference(other *Iterator[V], keyFunc func(V) any) *Iter
 		return true
 	})
 
-	return it.Filter(func(v V) bool {
+	difference := it.Filter(func(v V) bool {
 		_, ok := seen[keyFunc(v)]
 		return !ok
 	})
+	difference.err = firstErr(it.Err, other.Err)
+	difference.closer = closeAll(it.Close, other.Close)
+	return difference
 }
 
 // StepBy returns an iterator that yields every nth element (0-indexed).
@@ -821,6 +1101,8 @@ func (it *Iterator[V]) StepBy(n int) *Iterator[V] {
 				return true
 			})
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -843,6 +1125,8 @@ func (it *Iterator[V]) Shuffle() *Iterator[V] {
 				}
 			}
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
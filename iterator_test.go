@@ -1,7 +1,10 @@
 package itertools_test
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/amjadjibon/itertools"
@@ -996,3 +999,170 @@ func TestUniqueWithComplexKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestFromSeq(t *testing.T) {
+	iter := itertools.FromSeq(slices.Values([]int{1, 2, 3, 4}))
+	result := iter.Filter(func(x int) bool { return x%2 == 0 }).Collect()
+
+	assert.Equal(t, []int{2, 4}, result)
+}
+
+func TestFromSeq2(t *testing.T) {
+	iter := itertools.FromSeq2(slices.All([]string{"a", "b"}))
+	result := iter.Collect()
+
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, 0, result[0].First)
+	assert.Equal(t, "a", result[0].Second)
+	assert.Equal(t, 1, result[1].First)
+	assert.Equal(t, "b", result[1].Second)
+}
+
+func TestIterator_Seq(t *testing.T) {
+	iter := itertools.ToIter([]int{1, 2, 3, 4, 5}).Map(func(x int) int { return x * 10 })
+	result := slices.Collect(iter.Seq())
+
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, result)
+}
+
+func TestIterator_Seq_EarlyTermination(t *testing.T) {
+	var result []int
+	for v := range itertools.Range(0, 1000000).Seq() {
+		if v == 3 {
+			break
+		}
+		result = append(result, v)
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, result)
+}
+
+func TestIterator_Seq2(t *testing.T) {
+	iter := itertools.ToIter([]string{"a", "b", "c"}).Drop(1)
+
+	var indexes []int
+	var values []string
+	for i, v := range iter.Seq2() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []string{"b", "c"}, values)
+}
+
+func TestToSeq2(t *testing.T) {
+	keys := itertools.ToIter([]string{"a", "b", "c"})
+	values := itertools.ToIter([]int{1, 2, 3})
+	result := maps.Collect(itertools.ToSeq2(itertools.Zip(keys, values)))
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, result)
+}
+
+// trackedSeq returns a sequence over 0..n-1 that records when it is released.
+func trackedSeq(n int, released *bool) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		defer func() { *released = true }()
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestIterator_Close(t *testing.T) {
+	released := false
+	iter := itertools.FromSeq(trackedSeq(100, &released))
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, 0, iter.Current())
+	assert.False(t, released)
+
+	assert.NoError(t, iter.Close())
+	assert.True(t, released)
+	assert.False(t, iter.Next())
+	assert.NoError(t, iter.Close())
+}
+
+func TestIterator_Close_NotStarted(t *testing.T) {
+	iter := itertools.ToIter([]int{1, 2, 3})
+	assert.NoError(t, iter.Close())
+	assert.False(t, iter.Next())
+}
+
+func TestIterator_Close_StopsTerminalOperations(t *testing.T) {
+	runs := 0
+	iter := itertools.FromSeq(func(yield func(int) bool) {
+		runs++
+		yield(1)
+	})
+	seq := iter.Seq()
+	assert.NoError(t, iter.Close())
+
+	assert.Empty(t, iter.Collect())
+	iter.Each(func(int) { t.Fatal("Each ran after Close") })
+	for range seq {
+		t.Fatal("Seq ran after Close")
+	}
+	for range iter.Seq2() {
+		t.Fatal("Seq2 ran after Close")
+	}
+	assert.Equal(t, 0, runs)
+}
+
+func TestIterator_First_Closes(t *testing.T) {
+	released := false
+	first := itertools.FromSeq(trackedSeq(100, &released)).First()
+
+	assert.Equal(t, 0, first)
+	assert.True(t, released)
+}
+
+func TestIterator_OnClose(t *testing.T) {
+	calls := 0
+	iter := itertools.ToIter([]int{1, 2, 3}).
+		OnClose(func() error {
+			calls++
+			return nil
+		}).
+		Filter(func(x int) bool { return x > 1 })
+
+	assert.Equal(t, 2, iter.Nth(0))
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, iter.Close())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterator_OnClose_Error(t *testing.T) {
+	errClose := errors.New("close failed")
+	iter := itertools.ToIter([]int{1}).OnClose(func() error { return errClose })
+
+	assert.ErrorIs(t, iter.Close(), errClose)
+}
+
+func TestIterator_FirstOr_Closes(t *testing.T) {
+	calls := 0
+	iter := itertools.Range(0, 10).OnClose(func() error {
+		calls++
+		return nil
+	})
+
+	assert.Equal(t, 0, iter.FirstOr(-1))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterator_Close_Chain(t *testing.T) {
+	var closed []string
+	hook := func(name string) func() error {
+		return func() error {
+			closed = append(closed, name)
+			return nil
+		}
+	}
+	iter := itertools.ToIter([]int{1}).OnClose(hook("a")).
+		Chain(itertools.ToIter([]int{2}).OnClose(hook("b")))
+
+	assert.NoError(t, iter.Close())
+	assert.Equal(t, []string{"a", "b"}, closed)
+}
@@ -69,6 +69,8 @@ func Zip[A, B any](it1 *Iterator[A], it2 *Iterator[B]) *Iterator[struct {
 				}
 			}
 		},
+		err:    firstErr(it1.Err, it2.Err),
+		closer: closeAll(it1.Close, it2.Close),
 	}
 }
 
@@ -135,6 +137,249 @@ func Zip2[A, B any](it1 *Iterator[A], it2 *Iterator[B], fill struct {
 				}
 			}
 		},
+		err:    firstErr(it1.Err, it2.Err),
+		closer: closeAll(it1.Close, it2.Close),
+	}
+}
+
+// MapTo transforms each element using the provided function and returns an
+// iterator of a possibly different type. Unlike the Map method, the function
+// may change the element type.
+//
+// MapTo is lazy - the transformation is applied only as elements are consumed.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3})
+//	labels := itertools.MapTo(iter, func(x int) string {
+//	    return fmt.Sprintf("#%d", x)
+//	}).Collect()
+//	// labels is []string{"#1", "#2", "#3"}
+func MapTo[V, U any](it *Iterator[V], f func(V) U) *Iterator[U] {
+	return &Iterator[U]{
+		seq: func(yield func(U) bool) {
+			it.seq(func(v V) bool {
+				return yield(f(v))
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// FlatMap maps each element to an iterator and yields the elements of every
+// resulting iterator in order.
+//
+// FlatMap is lazy and supports early termination - the remaining inner
+// iterators are not consumed once iteration stops. Each inner iterator is
+// closed once it has been consumed. An error reported by an inner iterator
+// stops iteration and is returned by Err.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3})
+//	result := itertools.FlatMap(iter, func(x int) *itertools.Iterator[int] {
+//	    return itertools.Repeat(x, x)
+//	}).Collect()
+//	// result is []int{1, 2, 2, 3, 3, 3}
+func FlatMap[V, U any](it *Iterator[V], f func(V) *Iterator[U]) *Iterator[U] {
+	var innerErr error
+	return &Iterator[U]{
+		seq: func(yield func(U) bool) {
+			innerErr = nil
+			it.seq(func(v V) bool {
+				shouldContinue := true
+				inner := f(v)
+				inner.seq(func(u U) bool {
+					if !yield(u) {
+						shouldContinue = false
+						return false
+					}
+					return true
+				})
+				_ = inner.Close()
+				if err := inner.Err(); err != nil {
+					innerErr = err
+					return false
+				}
+				return shouldContinue
+			})
+		},
+		err:    firstErr(func() error { return innerErr }, it.Err),
+		closer: it.Close,
+	}
+}
+
+// FlatMapSlice maps each element to a slice and yields the elements of every
+// resulting slice in order.
+//
+// FlatMapSlice is lazy and supports early termination.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"a b", "c"})
+//	words := itertools.FlatMapSlice(iter, strings.Fields).Collect()
+//	// words is []string{"a", "b", "c"}
+func FlatMapSlice[V, U any](it *Iterator[V], f func(V) []U) *Iterator[U] {
+	return &Iterator[U]{
+		seq: func(yield func(U) bool) {
+			it.seq(func(v V) bool {
+				for _, u := range f(v) {
+					if !yield(u) {
+						return false
+					}
+				}
+				return true
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// FilterMap applies the function to each element and yields the results for
+// which the function returns true. It combines Filter and MapTo in one step.
+//
+// FilterMap is lazy - the function is applied only as elements are consumed.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"1", "x", "3"})
+//	nums := itertools.FilterMap(iter, func(s string) (int, bool) {
+//	    n, err := strconv.Atoi(s)
+//	    return n, err == nil
+//	}).Collect()
+//	// nums is []int{1, 3}
+func FilterMap[V, U any](it *Iterator[V], f func(V) (U, bool)) *Iterator[U] {
+	return &Iterator[U]{
+		seq: func(yield func(U) bool) {
+			it.seq(func(v V) bool {
+				if u, ok := f(v); ok {
+					return yield(u)
+				}
+				return true
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// Group is a key and the elements that share it, as returned by GroupByKey.
+type Group[K comparable, V any] struct {
+	Key    K
+	Values []V
+}
+
+// GroupByKey consumes the iterator and groups its elements by key. Unlike
+// Iterator.GroupBy, keys can be of any comparable type and the groups are
+// returned in the order in which their keys were first seen.
+//
+// Note: This loads all elements into memory. For sorted input, consider
+// GroupConsecutive, which does not.
+//
+// Example:
+//
+//	type Person struct { Name string; Age int }
+//	iter := itertools.ToIter([]Person{
+//	    {"Alice", 30}, {"Bob", 25}, {"Charlie", 30},
+//	})
+//	byAge := itertools.GroupByKey(iter, func(p Person) int { return p.Age })
+//	// byAge is []Group[int, Person]{
+//	//     {30, []Person{{"Alice", 30}, {"Charlie", 30}}},
+//	//     {25, []Person{{"Bob", 25}}},
+//	// }
+func GroupByKey[V any, K comparable](it *Iterator[V], key func(V) K) []Group[K, V] {
+	var groups []Group[K, V]
+	index := make(map[K]int)
+	it.seq(func(v V) bool {
+		k := key(v)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group[K, V]{Key: k})
+		}
+		groups[i].Values = append(groups[i].Values, v)
+		return true
+	})
+	return groups
+}
+
+// ConsecutiveGroup is a run of consecutive elements with the same key, as
+// yielded by GroupConsecutive.
+type ConsecutiveGroup[K comparable, V any] struct {
+	Key    K
+	Values *Iterator[V]
+}
+
+// GroupConsecutive returns an iterator over the runs of consecutive elements
+// that share a key, like Python's itertools.groupby. Each group's Values
+// iterator reads the run straight from the input, so nothing is buffered and
+// a sorted input of any size can be grouped lazily.
+//
+// A group's Values must be consumed before moving on to the next group; once
+// the outer iterator advances, the rest of the run is skipped and Values
+// yields nothing. Equal keys that are not adjacent form separate groups.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"apple", "avocado", "banana", "blueberry", "cherry"})
+//	groups := itertools.GroupConsecutive(iter, func(s string) byte { return s[0] })
+//	for g := range groups.Seq() {
+//	    fmt.Println(string(g.Key), g.Values.Count())
+//	}
+//	// a 2
+//	// b 2
+//	// c 1
+func GroupConsecutive[V any, K comparable](it *Iterator[V], key func(V) K) *Iterator[ConsecutiveGroup[K, V]] {
+	return &Iterator[ConsecutiveGroup[K, V]]{
+		seq: func(yield func(ConsecutiveGroup[K, V]) bool) {
+			next, stop := iter.Pull(it.seq)
+			defer stop()
+
+			// cur is the next element of the input, and fresh is true until it
+			// has been yielded by a group's Values
+			var cur V
+			var curKey K
+			var ok, fresh bool
+			advance := func() {
+				if cur, ok = next(); ok {
+					curKey = key(cur)
+					fresh = true
+				}
+			}
+
+			group := 0
+			for advance(); ok; {
+				group++
+				id, k := group, curKey
+				values := &Iterator[V]{
+					seq: func(yield func(V) bool) {
+						for id == group && ok && curKey == k {
+							if !fresh {
+								advance()
+								continue
+							}
+							fresh = false
+							if !yield(cur) {
+								return
+							}
+						}
+					},
+					err: it.Err,
+				}
+				if !yield(ConsecutiveGroup[K, V]{Key: k, Values: values}) {
+					return
+				}
+				// Skip whatever is left of the run
+				for ok && curKey == k {
+					advance()
+				}
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -228,6 +473,8 @@ func ChunkSlice[V any](it *Iterator[V], size int) *Iterator[[]V] {
 				yield(result)
 			}
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -271,6 +518,8 @@ func Chunks[V any](it *Iterator[V], size int) *Iterator[*Iterator[V]] {
 				yield(ToIter(result))
 			}
 		},
+		err:    it.Err,
+		closer: it.Close,
 	}
 }
 
@@ -286,11 +535,169 @@ func ChunkList[V any](it *Iterator[V], size int) []*Iterator[V] {
 	return Chunks(it, size).Collect()
 }
 
+// Windows returns an iterator over sliding windows of size consecutive
+// elements, starting a new window every step elements. With step equal to
+// size the windows are tumbling, like ChunkSlice; with a larger step some
+// elements fall between windows. Only full windows are yielded, so an input
+// shorter than size yields nothing, and so does a size or step below 1.
+//
+// The last size elements are held in a ring buffer, so memory stays constant
+// however long the input is. Each window is a separate slice.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3, 4, 5})
+//	windows := itertools.Windows(iter, 3, 1).Collect()
+//	// windows is [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+func Windows[V any](it *Iterator[V], size, step int) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			if size < 1 || step < 1 {
+				return
+			}
+			ring := make([]V, size)
+			n := 0
+			it.seq(func(v V) bool {
+				ring[n%size] = v
+				n++
+				// The window ending here starts at n-size
+				if n < size || (n-size)%step != 0 {
+					return true
+				}
+				window := make([]V, size)
+				start := n % size
+				copy(window, ring[start:])
+				copy(window[size-start:], ring[:start])
+				return yield(window)
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// Pairwise returns an iterator over the overlapping pairs of consecutive
+// elements, like Python's itertools.pairwise. An input of n elements yields
+// n-1 pairs.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 4, 9, 16})
+//	deltas := itertools.MapTo(itertools.Pairwise(iter), func(p struct{ First, Second int }) int {
+//	    return p.Second - p.First
+//	}).Collect()
+//	// deltas is []int{3, 5, 7}
+func Pairwise[V any](it *Iterator[V]) *Iterator[struct {
+	First  V
+	Second V
+}] {
+	return &Iterator[struct {
+		First  V
+		Second V
+	}]{
+		seq: func(yield func(struct {
+			First  V
+			Second V
+		}) bool,
+		) {
+			var prev V
+			started := false
+			it.seq(func(v V) bool {
+				if !started {
+					prev, started = v, true
+					return true
+				}
+				pair := struct {
+					First  V
+					Second V
+				}{prev, v}
+				prev = v
+				return yield(pair)
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// ChunkBy returns an iterator over slices of consecutive elements, starting
+// a new slice wherever split returns true for an element and the one before
+// it. Each chunk is a separate slice.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]int{1, 2, 3, 7, 8, 12})
+//	runs := itertools.ChunkBy(iter, func(prev, curr int) bool { return curr-prev > 1 }).Collect()
+//	// runs is [][]int{{1, 2, 3}, {7, 8}, {12}}
+func ChunkBy[V any](it *Iterator[V], split func(prev, curr V) bool) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			var chunk []V
+			ok := true
+			it.seq(func(v V) bool {
+				if len(chunk) > 0 && split(chunk[len(chunk)-1], v) {
+					if ok = yield(chunk); !ok {
+						return false
+					}
+					chunk = nil
+				}
+				chunk = append(chunk, v)
+				return true
+			})
+			if ok && len(chunk) > 0 {
+				yield(chunk)
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// ChunkByWeight returns an iterator over slices of consecutive elements
+// whose weights add up to at most maxWeight, such as batches of requests
+// that fit a payload limit. An element that weighs more than maxWeight on
+// its own is yielded in a chunk by itself. Each chunk is a separate slice.
+//
+// Example:
+//
+//	payloads := itertools.ToIter(records)
+//	batches := itertools.ChunkByWeight(payloads, 1<<20, func(p []byte) int { return len(p) })
+//	for batch := range batches.Seq() {
+//	    send(batch) // at most 1 MiB, unless a single record is larger
+//	}
+func ChunkByWeight[V any](it *Iterator[V], maxWeight int, weight func(V) int) *Iterator[[]V] {
+	return &Iterator[[]V]{
+		seq: func(yield func([]V) bool) {
+			var chunk []V
+			total := 0
+			ok := true
+			it.seq(func(v V) bool {
+				w := weight(v)
+				if len(chunk) > 0 && total+w > maxWeight {
+					if ok = yield(chunk); !ok {
+						return false
+					}
+					chunk, total = nil, 0
+				}
+				chunk = append(chunk, v)
+				total += w
+				return true
+			})
+			if ok && len(chunk) > 0 {
+				yield(chunk)
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
 // Flatten concatenates multiple iterators into a single iterator.
 // Elements are yielded in order: all elements from the first iterator,
 // then all from the second, and so on.
 //
-// Properly handles early termination - stops immediately when yield returns false.
+// Properly handles early termination - stops immediately when yield returns false
+// or when one of the iterators stops with an error.
 //
 // Example:
 //
@@ -300,6 +707,12 @@ func ChunkList[V any](it *Iterator[V], size int) []*Iterator[V] {
 //	flattened := itertools.Flatten(iter1, iter2, iter3).Collect()
 //	// flattened is []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
 func Flatten[V any](its ...*Iterator[V]) *Iterator[V] {
+	errs := make([]func() error, len(its))
+	closers := make([]func() error, len(its))
+	for i, it := range its {
+		errs[i] = it.Err
+		closers[i] = it.Close
+	}
 	return &Iterator[V]{
 		seq: func(yield func(V) bool) {
 			for _, it := range its {
@@ -311,11 +724,13 @@ func Flatten[V any](its ...*Iterator[V]) *Iterator[V] {
 					}
 					return true
 				})
-				if !shouldContinue {
+				if !shouldContinue || it.Err() != nil {
 					return
 				}
 			}
 		},
+		err:    firstErr(errs...),
+		closer: closeAll(closers...),
 	}
 }
 
@@ -355,5 +770,7 @@ func CartesianProduct[A, B any](it1 *Iterator[A], it2 *Iterator[B]) *Iterator[st
 				return true
 			})
 		},
+		err:    firstErr(it1.Err, it2.Err),
+		closer: closeAll(it1.Close, it2.Close),
 	}
 }
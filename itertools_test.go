@@ -1,8 +1,11 @@
 package itertools_test
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -363,3 +366,246 @@ func TestChunkList_Functional(t *testing.T) {
 	assert.Equal(t, []int{6, 7, 8}, chunks[2].Collect())
 	assert.Equal(t, []int{9}, chunks[3].Collect())
 }
+
+func TestMapTo(t *testing.T) {
+	iter := itertools.ToIter([]int{1, 2, 3})
+	result := itertools.MapTo(iter, func(x int) string { return strconv.Itoa(x * 10) }).Collect()
+
+	assert.Equal(t, []string{"10", "20", "30"}, result)
+}
+
+func TestMapTo_EarlyTermination(t *testing.T) {
+	calls := 0
+	result := itertools.MapTo(itertools.Range(0, 1000000), func(x int) float64 {
+		calls++
+		return float64(x) / 2
+	}).Take(3).Collect()
+
+	assert.Equal(t, []float64{0, 0.5, 1}, result)
+	assert.Less(t, calls, 10)
+}
+
+func TestFlatMap(t *testing.T) {
+	iter := itertools.ToIter([]int{1, 2, 3})
+	result := itertools.FlatMap(iter, func(x int) *itertools.Iterator[string] {
+		return itertools.Repeat(strconv.Itoa(x), x)
+	}).Collect()
+
+	assert.Equal(t, []string{"1", "2", "2", "3", "3", "3"}, result)
+}
+
+func TestFlatMap_EarlyTermination(t *testing.T) {
+	opened := 0
+	result := itertools.FlatMap(itertools.Range(0, 1000000), func(x int) *itertools.Iterator[int] {
+		opened++
+		return itertools.Range(x*10, x*10+3)
+	}).Take(4).Collect()
+
+	assert.Equal(t, []int{0, 1, 2, 10}, result)
+	assert.Equal(t, 2, opened)
+}
+
+func TestFlatMapSlice(t *testing.T) {
+	iter := itertools.ToIter([]string{"a b", "", "c d e"})
+	result := itertools.FlatMapSlice(iter, strings.Fields).Collect()
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, result)
+
+	first := itertools.FlatMapSlice(itertools.ToIter([]string{"a b", "c d"}), strings.Fields).Take(3).Collect()
+	assert.Equal(t, []string{"a", "b", "c"}, first)
+}
+
+func TestFilterMap(t *testing.T) {
+	iter := itertools.ToIter([]string{"1", "x", "3", "", "5"})
+	result := itertools.FilterMap(iter, func(s string) (int, bool) {
+		n, err := strconv.Atoi(s)
+		return n, err == nil
+	}).Collect()
+
+	assert.Equal(t, []int{1, 3, 5}, result)
+}
+
+func TestGroupByKey(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	iter := itertools.ToIter([]person{{"Alice", 30}, {"Bob", 25}, {"Charlie", 30}, {"Dave", 40}})
+
+	groups := itertools.GroupByKey(iter, func(p person) int { return p.Age })
+
+	assert.Equal(t, []itertools.Group[int, person]{
+		{Key: 30, Values: []person{{"Alice", 30}, {"Charlie", 30}}},
+		{Key: 25, Values: []person{{"Bob", 25}}},
+		{Key: 40, Values: []person{{"Dave", 40}}},
+	}, groups)
+}
+
+func TestGroupByKey_StructKey(t *testing.T) {
+	type key struct {
+		Even  bool
+		Large bool
+	}
+	groups := itertools.GroupByKey(itertools.Range(0, 20), func(x int) key {
+		return key{x%2 == 0, x >= 10}
+	})
+
+	assert.Equal(t, 4, len(groups))
+	assert.Equal(t, key{true, false}, groups[0].Key)
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, groups[0].Values)
+	assert.Equal(t, key{false, true}, groups[3].Key)
+}
+
+func TestGroupByKey_Empty(t *testing.T) {
+	groups := itertools.GroupByKey(itertools.ToIter([]int{}), func(x int) int { return x })
+	assert.Empty(t, groups)
+}
+
+func TestGroupConsecutive(t *testing.T) {
+	iter := itertools.ToIter([]int{1, 1, 2, 3, 3, 3, 1})
+
+	var keys []int
+	var runs [][]int
+	for g := range itertools.GroupConsecutive(iter, func(x int) int { return x }).Seq() {
+		keys = append(keys, g.Key)
+		runs = append(runs, g.Values.Collect())
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 1}, keys)
+	assert.Equal(t, [][]int{{1, 1}, {2}, {3, 3, 3}, {1}}, runs)
+}
+
+func TestGroupConsecutive_SkipsUnconsumedValues(t *testing.T) {
+	words := itertools.ToIter([]string{"apple", "avocado", "banana", "blueberry", "cherry"})
+	groups := itertools.GroupConsecutive(words, func(s string) byte { return s[0] })
+
+	var firsts []string
+	var stale []*itertools.Iterator[string]
+	for g := range groups.Seq() {
+		if g.Key == 'b' {
+			// Leave the whole run unread
+			stale = append(stale, g.Values)
+			continue
+		}
+		firsts = append(firsts, g.Values.Take(1).Collect()...)
+	}
+
+	assert.Equal(t, []string{"apple", "cherry"}, firsts)
+	assert.Empty(t, stale[0].Collect())
+}
+
+func TestGroupConsecutive_Lazy(t *testing.T) {
+	reads := 0
+	iter := itertools.FromFunc(func() (int, bool) {
+		reads++
+		return reads / 3, true
+	})
+
+	groups := itertools.GroupConsecutive(iter, func(x int) int { return x })
+	first := groups.Take(2).Collect()
+
+	assert.Equal(t, 0, first[0].Key)
+	assert.Equal(t, 1, first[1].Key)
+	assert.Less(t, reads, 20)
+}
+
+func TestGroupConsecutive_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "a\na\nb\n", err: errBroken})
+	groups := itertools.GroupConsecutive(lines, func(s string) string { return s })
+
+	var runs [][]string
+	for g := range groups.Seq() {
+		runs = append(runs, g.Values.Collect())
+	}
+
+	assert.Equal(t, [][]string{{"a", "a"}, {"b"}}, runs)
+	assert.ErrorIs(t, groups.Err(), errBroken)
+}
+
+func TestWindows(t *testing.T) {
+	windows := itertools.Windows(itertools.Range(1, 6), 3, 1).Collect()
+
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, windows)
+}
+
+func TestWindows_Step(t *testing.T) {
+	assert.Equal(t, [][]int{{0, 1, 2}, {2, 3, 4}, {4, 5, 6}},
+		itertools.Windows(itertools.Range(0, 8), 3, 2).Collect())
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4, 5}},
+		itertools.Windows(itertools.Range(0, 7), 2, 2).Collect())
+	assert.Equal(t, [][]int{{0, 1}, {5, 6}},
+		itertools.Windows(itertools.Range(0, 9), 2, 5).Collect())
+}
+
+func TestWindows_EdgeCases(t *testing.T) {
+	assert.Empty(t, itertools.Windows(itertools.Range(0, 2), 3, 1).Collect())
+	assert.Empty(t, itertools.Windows(itertools.Range(0, 5), 0, 1).Collect())
+	assert.Empty(t, itertools.Windows(itertools.Range(0, 5), 2, 0).Collect())
+}
+
+func TestWindows_Independent(t *testing.T) {
+	windows := itertools.Windows(itertools.Range(0, 4), 2, 1).Collect()
+	windows[0][1] = 100
+
+	assert.Equal(t, []int{1, 2}, windows[1])
+}
+
+func TestWindows_RollingMean(t *testing.T) {
+	latencies := itertools.ToIter([]float64{10, 20, 30, 40, 50})
+	means := itertools.MapTo(itertools.Windows(latencies, 2, 1), func(w []float64) float64 {
+		return (w[0] + w[1]) / 2
+	}).Collect()
+
+	assert.Equal(t, []float64{15, 25, 35, 45}, means)
+}
+
+func TestPairwise(t *testing.T) {
+	deltas := itertools.MapTo(itertools.Pairwise(itertools.ToIter([]int{1, 4, 9, 16})), func(p struct{ First, Second int }) int {
+		return p.Second - p.First
+	}).Collect()
+
+	assert.Equal(t, []int{3, 5, 7}, deltas)
+	assert.Empty(t, itertools.Pairwise(itertools.ToIter([]int{1})).Collect())
+}
+
+func TestPairwise_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	pairs := itertools.Pairwise(itertools.FromReader(&errReader{data: "a\nb\nc\n", err: errBroken}))
+
+	assert.Equal(t, 2, pairs.Count())
+	assert.ErrorIs(t, pairs.Err(), errBroken)
+}
+
+func TestChunkBy(t *testing.T) {
+	runs := itertools.ChunkBy(itertools.ToIter([]int{1, 2, 3, 7, 8, 12}), func(prev, curr int) bool {
+		return curr-prev > 1
+	}).Collect()
+
+	assert.Equal(t, [][]int{{1, 2, 3}, {7, 8}, {12}}, runs)
+	assert.Empty(t, itertools.ChunkBy(itertools.ToIter([]int{}), func(prev, curr int) bool { return true }).Collect())
+}
+
+func TestChunkBy_EarlyTermination(t *testing.T) {
+	calls := 0
+	first := itertools.ChunkBy(itertools.Range(0, 100), func(prev, curr int) bool {
+		calls++
+		return curr%10 == 0
+	}).Take(1).Collect()
+
+	assert.Equal(t, [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}, first)
+	assert.Less(t, calls, 30)
+}
+
+func TestChunkByWeight(t *testing.T) {
+	payloads := itertools.ToIter([]string{"aaa", "bb", "cccc", "d", "eeeeeeeee", "ff"})
+	batches := itertools.ChunkByWeight(payloads, 6, func(s string) int { return len(s) }).Collect()
+
+	assert.Equal(t, [][]string{{"aaa", "bb"}, {"cccc", "d"}, {"eeeeeeeee"}, {"ff"}}, batches)
+}
+
+func TestChunkByWeight_ExactFit(t *testing.T) {
+	batches := itertools.ChunkByWeight(itertools.Range(0, 6), 3, func(int) int { return 1 }).Collect()
+
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}}, batches)
+}
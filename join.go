@@ -0,0 +1,221 @@
+package itertools
+
+import "iter"
+
+// JoinKind selects which unmatched elements a join keeps.
+type JoinKind int
+
+const (
+	// InnerJoin keeps only pairs of elements with equal keys.
+	InnerJoin JoinKind = iota
+	// LeftJoin also keeps left elements without a match.
+	LeftJoin
+	// RightJoin also keeps right elements without a match.
+	RightJoin
+	// FullJoin keeps unmatched elements from both sides.
+	FullJoin
+)
+
+func (k JoinKind) keepsLeft() bool {
+	return k == LeftJoin || k == FullJoin
+}
+
+func (k JoinKind) keepsRight() bool {
+	return k == RightJoin || k == FullJoin
+}
+
+// JoinRow is one row of a join. HasLeft and HasRight report which sides are
+// present; in outer joins, the missing side holds its zero value.
+type JoinRow[L, R any] struct {
+	Left     L
+	Right    R
+	HasLeft  bool
+	HasRight bool
+}
+
+// HashJoin joins two iterators on equal keys, like a SQL join. Both inputs
+// are read in step until one of them ends; that smaller side is then held in
+// a hash table and the other side is streamed past it, so memory is bounded
+// by twice the size of the smaller input.
+//
+// Rows follow the order of the larger input, with every match of an element
+// in the order of the smaller input. Unmatched elements of the smaller side,
+// kept by outer joins, come last.
+//
+// Example:
+//
+//	type Order struct { ID, CustomerID int }
+//	type Customer struct { ID int; Name string }
+//	rows := itertools.HashJoin(orders, customers,
+//	    func(o Order) int { return o.CustomerID },
+//	    func(c Customer) int { return c.ID },
+//	    itertools.LeftJoin)
+//	for row := range rows.Seq() {
+//	    if row.HasRight {
+//	        fmt.Println(row.Left.ID, row.Right.Name)
+//	    }
+//	}
+func HashJoin[L, R any, K comparable](left *Iterator[L], right *Iterator[R], leftKey func(L) K, rightKey func(R) K, kind JoinKind) *Iterator[JoinRow[L, R]] {
+	return &Iterator[JoinRow[L, R]]{
+		seq: func(yield func(JoinRow[L, R]) bool) {
+			nextL, stopL := iter.Pull(left.seq)
+			defer stopL()
+			nextR, stopR := iter.Pull(right.seq)
+			defer stopR()
+
+			var bufL []L
+			var bufR []R
+			for {
+				l, ok := nextL()
+				if !ok {
+					// The left side is smaller: build it and probe with the right
+					hashJoin(bufR, nextR, rightKey, bufL, leftKey, kind.keepsRight(), kind.keepsLeft(),
+						func(r R, hasR bool, l L, hasL bool) bool {
+							return yield(JoinRow[L, R]{Left: l, Right: r, HasLeft: hasL, HasRight: hasR})
+						})
+					return
+				}
+				bufL = append(bufL, l)
+
+				r, ok := nextR()
+				if !ok {
+					hashJoin(bufL, nextL, leftKey, bufR, rightKey, kind.keepsLeft(), kind.keepsRight(),
+						func(l L, hasL bool, r R, hasR bool) bool {
+							return yield(JoinRow[L, R]{Left: l, Right: r, HasLeft: hasL, HasRight: hasR})
+						})
+					return
+				}
+				bufR = append(bufR, r)
+			}
+		},
+		err:    firstErr(left.Err, right.Err),
+		closer: closeAll(left.Close, right.Close),
+	}
+}
+
+// hashJoin builds a hash table from the elements in build and joins every
+// probe element against it, first those already read into buf and then the
+// rest from next. keepProbe and keepBuild select the unmatched elements to
+// emit. It stops as soon as emit returns false.
+func hashJoin[P, B any, K comparable](buf []P, next func() (P, bool), probeKey func(P) K, build []B, buildKey func(B) K, keepProbe, keepBuild bool, emit func(P, bool, B, bool) bool) {
+	table := make(map[K][]int, len(build))
+	for i, b := range build {
+		k := buildKey(b)
+		table[k] = append(table[k], i)
+	}
+	matched := make([]bool, len(build))
+
+	var zeroP P
+	var zeroB B
+	probe := func(p P) bool {
+		indexes := table[probeKey(p)]
+		if len(indexes) == 0 && keepProbe {
+			return emit(p, true, zeroB, false)
+		}
+		for _, i := range indexes {
+			matched[i] = true
+			if !emit(p, true, build[i], true) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, p := range buf {
+		if !probe(p) {
+			return
+		}
+	}
+	for p, ok := next(); ok; p, ok = next() {
+		if !probe(p) {
+			return
+		}
+	}
+	if keepBuild {
+		for i, b := range build {
+			if !matched[i] && !emit(zeroP, false, b, true) {
+				return
+			}
+		}
+	}
+}
+
+// MergeJoin joins two iterators that are both sorted by key in the order
+// defined by compare. It streams both inputs, holding only the right
+// elements that share the current key, so memory stays constant unless a
+// key repeats many times on the right.
+//
+// compare must return a negative number, zero or a positive number when its
+// first argument sorts before, with or after the second, as cmp.Compare
+// does. The result is undefined if either input is not sorted.
+//
+// Example:
+//
+//	rows := itertools.MergeJoin(orders, customers,
+//	    func(o Order) int { return o.CustomerID },
+//	    func(c Customer) int { return c.ID },
+//	    cmp.Compare[int],
+//	    itertools.InnerJoin)
+func MergeJoin[L, R, K any](left *Iterator[L], right *Iterator[R], leftKey func(L) K, rightKey func(R) K, compare func(a, b K) int, kind JoinKind) *Iterator[JoinRow[L, R]] {
+	return &Iterator[JoinRow[L, R]]{
+		seq: func(yield func(JoinRow[L, R]) bool) {
+			nextL, stopL := iter.Pull(left.seq)
+			defer stopL()
+			nextR, stopR := iter.Pull(right.seq)
+			defer stopR()
+
+			var l L
+			var r R
+			var lk, rk K
+			var okL, okR bool
+			advanceL := func() {
+				if l, okL = nextL(); okL {
+					lk = leftKey(l)
+				}
+			}
+			advanceR := func() {
+				if r, okR = nextR(); okR {
+					rk = rightKey(r)
+				}
+			}
+
+			advanceL()
+			advanceR()
+			for okL || okR {
+				if !okR || (okL && compare(lk, rk) < 0) {
+					if kind.keepsLeft() && !yield(JoinRow[L, R]{Left: l, HasLeft: true}) {
+						return
+					}
+					advanceL()
+					continue
+				}
+				if !okL || compare(lk, rk) > 0 {
+					if kind.keepsRight() && !yield(JoinRow[L, R]{Right: r, HasRight: true}) {
+						return
+					}
+					advanceR()
+					continue
+				}
+
+				// Equal keys: pair every left element of the run with the
+				// right elements of the run
+				k := rk
+				var run []R
+				for okR && compare(rk, k) == 0 {
+					run = append(run, r)
+					advanceR()
+				}
+				for okL && compare(lk, k) == 0 {
+					for _, rr := range run {
+						if !yield(JoinRow[L, R]{Left: l, Right: rr, HasLeft: true, HasRight: true}) {
+							return
+						}
+					}
+					advanceL()
+				}
+			}
+		},
+		err:    firstErr(left.Err, right.Err),
+		closer: closeAll(left.Close, right.Close),
+	}
+}
@@ -0,0 +1,152 @@
+package itertools_test
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID         int
+	CustomerID int
+}
+
+type customer struct {
+	ID   int
+	Name string
+}
+
+// Both inputs are sorted by customer ID
+var (
+	joinOrders    = []order{{1, 10}, {2, 20}, {3, 20}, {4, 40}}
+	joinCustomers = []customer{{10, "acme"}, {20, "globex"}, {30, "initech"}}
+)
+
+func orderKey(o order) int       { return o.CustomerID }
+func customerKey(c customer) int { return c.ID }
+
+func joinPairs(rows []itertools.JoinRow[order, customer]) [][2]int {
+	pairs := make([][2]int, len(rows))
+	for i, row := range rows {
+		pairs[i] = [2]int{-1, -1}
+		if row.HasLeft {
+			pairs[i][0] = row.Left.ID
+		}
+		if row.HasRight {
+			pairs[i][1] = row.Right.ID
+		}
+	}
+	return pairs
+}
+
+func TestHashJoin(t *testing.T) {
+	tests := []struct {
+		kind     itertools.JoinKind
+		expected [][2]int
+	}{
+		{itertools.InnerJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}}},
+		{itertools.LeftJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {4, -1}}},
+		{itertools.RightJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {-1, 30}}},
+		{itertools.FullJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {4, -1}, {-1, 30}}},
+	}
+	for _, tt := range tests {
+		rows := itertools.HashJoin(itertools.ToIter(joinOrders), itertools.ToIter(joinCustomers),
+			orderKey, customerKey, tt.kind).Collect()
+		assert.Equal(t, tt.expected, joinPairs(rows), "kind %d", tt.kind)
+	}
+}
+
+func TestHashJoin_SmallerLeft(t *testing.T) {
+	// The left side ends first, so it is the one held in memory
+	orders := []order{{1, 20}, {2, 99}}
+
+	rows := itertools.HashJoin(itertools.ToIter(orders), itertools.ToIter(joinCustomers),
+		orderKey, customerKey, itertools.FullJoin).Collect()
+
+	assert.Equal(t, [][2]int{{-1, 10}, {1, 20}, {-1, 30}, {2, -1}}, joinPairs(rows))
+	assert.Equal(t, "globex", rows[1].Right.Name)
+}
+
+func TestHashJoin_ManyToMany(t *testing.T) {
+	left := itertools.ToIter([]int{1, 1, 2})
+	right := itertools.ToIter([]int{1, 1, 1, 3})
+	id := func(x int) int { return x }
+
+	rows := itertools.HashJoin(left, right, id, id, itertools.InnerJoin).Collect()
+
+	assert.Equal(t, 6, len(rows))
+}
+
+func TestHashJoin_EarlyTermination(t *testing.T) {
+	left := itertools.Range(0, 1000000)
+	right := itertools.Range(0, 10)
+	id := func(x int) int { return x }
+
+	rows := itertools.HashJoin(left, right, id, id, itertools.InnerJoin).Take(3).Collect()
+
+	assert.Equal(t, 3, len(rows))
+}
+
+func TestHashJoin_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	left := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken})
+	right := itertools.ToIter([]string{"a", "b", "c"})
+	id := func(s string) string { return s }
+
+	rows := itertools.HashJoin(left, right, id, id, itertools.InnerJoin)
+
+	assert.Equal(t, 2, len(rows.Collect()))
+	assert.ErrorIs(t, rows.Err(), errBroken)
+}
+
+func TestMergeJoin(t *testing.T) {
+	tests := []struct {
+		kind     itertools.JoinKind
+		expected [][2]int
+	}{
+		{itertools.InnerJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}}},
+		{itertools.LeftJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {4, -1}}},
+		{itertools.RightJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {-1, 30}}},
+		{itertools.FullJoin, [][2]int{{1, 10}, {2, 20}, {3, 20}, {-1, 30}, {4, -1}}},
+	}
+	for _, tt := range tests {
+		rows := itertools.MergeJoin(itertools.ToIter(joinOrders), itertools.ToIter(joinCustomers),
+			orderKey, customerKey, cmp.Compare[int], tt.kind).Collect()
+		assert.Equal(t, tt.expected, joinPairs(rows), "kind %d", tt.kind)
+	}
+}
+
+func TestMergeJoin_ManyToMany(t *testing.T) {
+	left := itertools.ToIter([]int{1, 1, 2, 4})
+	right := itertools.ToIter([]int{0, 1, 1, 1, 4, 4})
+	id := func(x int) int { return x }
+
+	rows := itertools.MergeJoin(left, right, id, id, cmp.Compare[int], itertools.InnerJoin).Collect()
+
+	assert.Equal(t, 8, len(rows))
+	for _, row := range rows {
+		assert.Equal(t, row.Left, row.Right)
+	}
+}
+
+func TestMergeJoin_Streams(t *testing.T) {
+	// Both sides are infinite, so only a streaming join can produce rows
+	evens := itertools.Generate(func() func() int {
+		n := -2
+		return func() int { n += 2; return n }
+	}())
+	triples := itertools.Generate(func() func() int {
+		n := -3
+		return func() int { n += 3; return n }
+	}())
+	id := func(x int) int { return x }
+
+	rows := itertools.MergeJoin(evens, triples, id, id, cmp.Compare[int], itertools.InnerJoin).Take(3).Collect()
+
+	assert.Equal(t, []int{0, 6, 12}, itertools.MapTo(itertools.ToIter(rows), func(r itertools.JoinRow[int, int]) int {
+		return r.Left
+	}).Collect())
+}
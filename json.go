@@ -0,0 +1,257 @@
+package itertools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSONLineError reports a JSON Lines record that could not be decoded.
+// Line is the 1-based line number in the input.
+type JSONLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *JSONLineError) Error() string {
+	return fmt.Sprintf("itertools: json line %d: %v", e.Line, e.Err)
+}
+
+func (e *JSONLineError) Unwrap() error {
+	return e.Err
+}
+
+// JSONOption configures FromJSONLines.
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	skipInvalid bool
+}
+
+// WithSkipInvalidLines makes FromJSONLines skip lines that cannot be decoded
+// instead of stopping with a *JSONLineError. Read errors still stop iteration.
+func WithSkipInvalidLines() JSONOption {
+	return func(c *jsonConfig) {
+		c.skipInvalid = true
+	}
+}
+
+// FromJSONLines creates a lazy Iterator that decodes one value of type V per
+// line of a JSON Lines (NDJSON) input. Blank lines are ignored and lines of
+// any length are supported.
+//
+// By default a line that cannot be decoded stops iteration and Err returns a
+// *JSONLineError with its line number; use WithSkipInvalidLines to skip such
+// lines instead. The iterator does not close r; use OnClose to close it
+// together with the iterator.
+//
+// Example:
+//
+//	type Event struct {
+//	    Type string `json:"type"`
+//	    User string `json:"user"`
+//	}
+//	file, _ := os.Open("events.ndjson")
+//	iter := itertools.FromJSONLines[Event](file).OnClose(file.Close)
+//	defer iter.Close()
+//	logins := iter.Filter(func(e Event) bool { return e.Type == "login" }).Collect()
+//	if err := iter.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func FromJSONLines[V any](r io.Reader, opts ...JSONOption) *Iterator[V] {
+	var cfg jsonConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			br := bufio.NewReader(r)
+			line := 0
+			for {
+				data, readErr := br.ReadBytes('\n')
+				if readErr != nil && readErr != io.EOF {
+					err = readErr
+					return
+				}
+				line++
+				if data = bytes.TrimSpace(data); len(data) > 0 {
+					var v V
+					if decodeErr := json.Unmarshal(data, &v); decodeErr != nil {
+						if !cfg.skipInvalid {
+							err = &JSONLineError{Line: line, Err: decodeErr}
+							return
+						}
+					} else if !yield(v) {
+						return
+					}
+				}
+				if readErr == io.EOF {
+					return
+				}
+			}
+		},
+		err: func() error { return err },
+	}
+}
+
+// ToJSONLines consumes the iterator and writes each element to w as one line
+// of JSON. It returns the first encoding or write error, or the error that
+// stopped the iterator.
+//
+// Example:
+//
+//	file, _ := os.Create("active.ndjson")
+//	defer file.Close()
+//	w := bufio.NewWriter(file)
+//	err := itertools.ToJSONLines(users.Filter(isActive), w)
+//	if err == nil {
+//	    err = w.Flush()
+//	}
+func ToJSONLines[V any](it *Iterator[V], w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var err error
+	it.seq(func(v V) bool {
+		err = enc.Encode(v)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return it.Err()
+}
+
+// FromJSONArray creates a lazy Iterator that decodes the elements of a JSON
+// array one at a time, so memory stays constant however large the array is.
+// The array is either the whole document, when path is empty, or is reached
+// by following the dot-separated object keys in path, such as "data.items".
+// Values before the array are skipped without being decoded.
+//
+// A malformed document or an element that cannot be decoded into V stops
+// iteration; Err reports the error. The iterator does not close r; use
+// OnClose to close it together with the iterator.
+//
+// Example:
+//
+//	// {"data": {"total": 2, "items": [{"id": 1}, {"id": 2}]}}
+//	file, _ := os.Open("export.json")
+//	iter := itertools.FromJSONArray[Item](file, "data.items").OnClose(file.Close)
+//	defer iter.Close()
+//	first10 := iter.Take(10).Collect()
+func FromJSONArray[V any](r io.Reader, path string) *Iterator[V] {
+	return FromJSONArrayWithContext[V](context.Background(), r, path)
+}
+
+// FromJSONArrayWithContext creates a lazy Iterator over a JSON array with
+// context support. The iterator will stop when either the array is exhausted
+// or the context is cancelled, in which case Err returns the context's error.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	iter := itertools.FromJSONArrayWithContext[Item](ctx, resp.Body, "items")
+func FromJSONArrayWithContext[V any](ctx context.Context, r io.Reader, path string) *Iterator[V] {
+	var keys []string
+	if path != "" {
+		keys = strings.Split(path, ".")
+	}
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			dec := json.NewDecoder(r)
+			if err = seekJSONPath(dec, keys); err != nil {
+				return
+			}
+			if err = expectJSONDelim(dec, '['); err != nil {
+				return
+			}
+			for index := 0; dec.More(); index++ {
+				select {
+				case <-ctx.Done():
+					err = ctx.Err()
+					return
+				default:
+				}
+				var v V
+				if decodeErr := dec.Decode(&v); decodeErr != nil {
+					err = fmt.Errorf("itertools: json array element %d: %w", index, decodeErr)
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+			err = expectJSONDelim(dec, ']')
+		},
+		err: func() error { return err },
+	}
+}
+
+// seekJSONPath advances dec to the value found by following keys through
+// nested objects, skipping every other value on the way.
+func seekJSONPath(dec *json.Decoder, keys []string) error {
+	for depth, key := range keys {
+		if err := expectJSONDelim(dec, '{'); err != nil {
+			return err
+		}
+		for {
+			if !dec.More() {
+				return fmt.Errorf("itertools: json path %q not found", strings.Join(keys[:depth+1], "."))
+			}
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if tok == key {
+				break
+			}
+			if err := skipJSONValue(dec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipJSONValue consumes the next value from dec token by token, without
+// decoding it into memory.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// expectJSONDelim reads the next token from dec and checks that it is want.
+func expectJSONDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("itertools: expected %q at offset %d, got %v", want, dec.InputOffset(), tok)
+	}
+	return nil
+}
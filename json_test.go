@@ -0,0 +1,224 @@
+package itertools_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	Type string `json:"type"`
+	User string `json:"user"`
+}
+
+func TestFromJSONLines(t *testing.T) {
+	data := `{"type":"login","user":"alice"}
+{"type":"logout","user":"bob"}
+
+{"type":"login","user":"carol"}`
+
+	iter := itertools.FromJSONLines[event](strings.NewReader(data))
+	logins := iter.Filter(func(e event) bool { return e.Type == "login" }).Collect()
+
+	assert.Equal(t, []event{{"login", "alice"}, {"login", "carol"}}, logins)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromJSONLines_LongLine(t *testing.T) {
+	long := strings.Repeat("x", 200000)
+	data := `{"type":"a","user":"` + long + `"}` + "\r\n"
+
+	iter := itertools.FromJSONLines[event](strings.NewReader(data))
+	result := iter.Collect()
+
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, long, result[0].User)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromJSONLines_InvalidLineFails(t *testing.T) {
+	data := `{"type":"a"}
+{"type":
+{"type":"c"}`
+
+	iter := itertools.FromJSONLines[event](strings.NewReader(data))
+	result := iter.Collect()
+
+	assert.Equal(t, []event{{Type: "a"}}, result)
+
+	var lineErr *itertools.JSONLineError
+	assert.True(t, errors.As(iter.Err(), &lineErr))
+	assert.Equal(t, 2, lineErr.Line)
+
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(iter.Err(), &syntaxErr))
+}
+
+func TestFromJSONLines_SkipInvalidLines(t *testing.T) {
+	data := `{"type":"a"}
+not json
+{"type":"c"}`
+
+	iter := itertools.FromJSONLines[event](strings.NewReader(data), itertools.WithSkipInvalidLines())
+	result := iter.Collect()
+
+	assert.Equal(t, []event{{Type: "a"}, {Type: "c"}}, result)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromJSONLines_ReadError(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.FromJSONLines[int](&errReader{data: "1\n2\n", err: errBroken})
+	result := iter.Collect()
+
+	assert.Equal(t, []int{1, 2}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestFromJSONLines_EarlyTermination(t *testing.T) {
+	data := strings.Repeat("{\"type\":\"x\"}\n", 1000)
+	result := itertools.FromJSONLines[event](strings.NewReader(data)).Take(3).Collect()
+
+	assert.Equal(t, 3, len(result))
+}
+
+func TestToJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	iter := itertools.ToIter([]event{{"login", "alice"}, {"logout", "bob"}})
+
+	err := itertools.ToJSONLines(iter, &buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"type\":\"login\",\"user\":\"alice\"}\n{\"type\":\"logout\",\"user\":\"bob\"}\n", buf.String())
+}
+
+func TestToJSONLines_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	err := itertools.ToJSONLines(itertools.Range(0, 5), &buf)
+	assert.NoError(t, err)
+
+	result := itertools.FromJSONLines[int](&buf).Collect()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, result)
+}
+
+func TestToJSONLines_Err(t *testing.T) {
+	var buf bytes.Buffer
+	errBroken := errors.New("broken")
+	iter := itertools.FromReader(&errReader{data: "a\n", err: errBroken})
+
+	err := itertools.ToJSONLines(iter, &buf)
+
+	assert.ErrorIs(t, err, errBroken)
+	assert.Equal(t, "\"a\"\n", buf.String())
+}
+
+type item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFromJSONArray(t *testing.T) {
+	data := `[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]`
+
+	iter := itertools.FromJSONArray[item](strings.NewReader(data), "")
+	result := iter.Collect()
+
+	assert.Equal(t, []item{{1, "a"}, {2, "b"}, {3, "c"}}, result)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromJSON_DoesNotCloseReader(t *testing.T) {
+	lines := &closeTracker{Reader: strings.NewReader(`{"type":"a"}` + "\n" + `{"type":"b"}`)}
+	assert.Equal(t, "a", itertools.FromJSONLines[event](lines).First().Type)
+	assert.False(t, lines.closed)
+
+	array := &closeTracker{Reader: strings.NewReader(`[{"id": 1}, {"id": 2}]`)}
+	assert.Equal(t, 1, itertools.FromJSONArray[item](array, "").First().ID)
+	assert.False(t, array.closed)
+}
+
+func TestFromJSONArray_Path(t *testing.T) {
+	data := `{
+		"meta": {"skip": [1, 2, {"items": "not this one"}]},
+		"data": {
+			"total": 2,
+			"items": [{"id": 1}, {"id": 2}],
+			"after": true
+		}
+	}`
+
+	iter := itertools.FromJSONArray[item](strings.NewReader(data), "data.items")
+	result := iter.Collect()
+
+	assert.Equal(t, []item{{ID: 1}, {ID: 2}}, result)
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromJSONArray_PathNotFound(t *testing.T) {
+	iter := itertools.FromJSONArray[item](strings.NewReader(`{"data": {"rows": []}}`), "data.items")
+	result := iter.Collect()
+
+	assert.Empty(t, result)
+	assert.ErrorContains(t, iter.Err(), `"data.items" not found`)
+}
+
+func TestFromJSONArray_NotAnArray(t *testing.T) {
+	iter := itertools.FromJSONArray[item](strings.NewReader(`{"id": 1}`), "")
+	iter.Collect()
+
+	assert.Error(t, iter.Err())
+}
+
+func TestFromJSONArray_DecodeError(t *testing.T) {
+	data := `[{"id": 1}, {"id": "two"}, {"id": 3}]`
+
+	iter := itertools.FromJSONArray[item](strings.NewReader(data), "")
+	result := iter.Collect()
+
+	assert.Equal(t, []item{{ID: 1}}, result)
+	assert.ErrorContains(t, iter.Err(), "element 1")
+
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(iter.Err(), &typeErr))
+}
+
+func TestFromJSONArray_Truncated(t *testing.T) {
+	iter := itertools.FromJSONArray[int](strings.NewReader(`[1, 2, 3`), "")
+	result := iter.Collect()
+
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.Error(t, iter.Err())
+}
+
+func TestFromJSONArray_EarlyTermination(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(`{"id": 1}`)
+	}
+	sb.WriteString("]")
+
+	result := itertools.FromJSONArray[item](strings.NewReader(sb.String()), "").Take(5).Collect()
+
+	assert.Equal(t, 5, len(result))
+}
+
+func TestFromJSONArrayWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter := itertools.FromJSONArrayWithContext[int](ctx, strings.NewReader(`[1, 2, 3]`), "")
+	result := iter.Collect()
+
+	assert.Empty(t, result)
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
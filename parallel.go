@@ -0,0 +1,193 @@
+package itertools
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelOption configures ParallelMap and ParallelFilter.
+type ParallelOption func(*parallelConfig)
+
+type parallelConfig struct {
+	ordered bool
+}
+
+// WithUnordered makes ParallelMap and ParallelFilter yield results in the
+// order they complete instead of the input order. This avoids holding back
+// fast results behind a slow element.
+func WithUnordered() ParallelOption {
+	return func(c *parallelConfig) {
+		c.ordered = false
+	}
+}
+
+// ParallelMap transforms each element using f on a pool of worker goroutines
+// and returns an iterator of the results. By default results are yielded in
+// input order; use WithUnordered to yield them in completion order.
+//
+// At most 2*workers elements are in flight at any time, so memory stays
+// bounded even when one element is slow. When the consumer stops early, for
+// example after Take, no new elements are handed to f, and iteration returns
+// once the calls to f already running have finished. The goroutine reading
+// the input may still be waiting for its next element at that point, such as
+// on an idle channel; it stops once that element arrives or the input ends,
+// so closing the iterator may close the input while that read is in
+// progress. The function f must be safe for concurrent use.
+//
+// Example:
+//
+//	urls := itertools.ToIter([]string{"a", "b", "c"})
+//	pages := itertools.ParallelMap(urls, 8, func(url string) Page {
+//	    return fetch(url)
+//	}).Collect()
+//	// pages are in the same order as urls
+func ParallelMap[V, U any](it *Iterator[V], workers int, f func(V) U, opts ...ParallelOption) *Iterator[U] {
+	if workers < 1 {
+		workers = 1
+	}
+	cfg := parallelConfig{ordered: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// The input is read on a separate goroutine, so its error is handed
+	// over under a lock.
+	var mu sync.Mutex
+	var srcErr error
+
+	return &Iterator[U]{
+		seq: func(yield func(U) bool) {
+			ctx, cancel := context.WithCancel(context.Background())
+			// Join the workers, so that f is not running after iteration
+			// returns. The feeder is not joined, as it may be blocked reading
+			// an idle input; it stops at its next element.
+			var wg sync.WaitGroup
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			type task struct {
+				index int
+				value V
+			}
+			type result struct {
+				index int
+				value U
+			}
+			tasks := make(chan task)
+			results := make(chan result, workers)
+			// slots bounds the number of elements read but not yet yielded
+			slots := make(chan struct{}, 2*workers)
+
+			go func() {
+				defer close(tasks)
+				index := 0
+				it.seq(func(v V) bool {
+					select {
+					case slots <- struct{}{}:
+					case <-ctx.Done():
+						return false
+					}
+					select {
+					case tasks <- task{index: index, value: v}:
+						index++
+						return true
+					case <-ctx.Done():
+						return false
+					}
+				})
+				mu.Lock()
+				srcErr = it.Err()
+				mu.Unlock()
+			}()
+
+			wg.Add(workers)
+			for range workers {
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						case t, ok := <-tasks:
+							if !ok {
+								return
+							}
+							select {
+							case results <- result{index: t.index, value: f(t.value)}:
+							case <-ctx.Done():
+								return
+							}
+						}
+					}
+				}()
+			}
+			go func() {
+				wg.Wait()
+				close(results)
+			}()
+
+			emit := func(u U) bool {
+				<-slots
+				return yield(u)
+			}
+
+			if !cfg.ordered {
+				for r := range results {
+					if !emit(r.value) {
+						return
+					}
+				}
+				return
+			}
+
+			// Hold completed results until every earlier element is yielded
+			pending := make(map[int]U)
+			next := 0
+			for r := range results {
+				pending[r.index] = r.value
+				for {
+					u, ok := pending[next]
+					if !ok {
+						break
+					}
+					delete(pending, next)
+					next++
+					if !emit(u) {
+						return
+					}
+				}
+			}
+		},
+		err: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			return srcErr
+		},
+		closer: it.Close,
+	}
+}
+
+// ParallelFilter evaluates the predicate on a pool of worker goroutines and
+// yields the elements for which it returns true. It accepts the same options
+// and offers the same guarantees as ParallelMap.
+//
+// Example:
+//
+//	rows := itertools.FromCSV(csv.NewReader(file))
+//	valid := itertools.ParallelFilter(rows, 16, func(row []string) bool {
+//	    return lookupCustomer(row[0]) // I/O-bound check
+//	}).Collect()
+func ParallelFilter[V any](it *Iterator[V], workers int, predicate func(V) bool, opts ...ParallelOption) *Iterator[V] {
+	type checked struct {
+		value V
+		keep  bool
+	}
+	results := ParallelMap(it, workers, func(v V) checked {
+		return checked{value: v, keep: predicate(v)}
+	}, opts...)
+	return FilterMap(results, func(c checked) (V, bool) {
+		return c.value, c.keep
+	})
+}
@@ -0,0 +1,118 @@
+package itertools_test
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelMap(t *testing.T) {
+	iter := itertools.Range(0, 100)
+	result := itertools.ParallelMap(iter, 8, func(x int) int {
+		// Make later elements finish first
+		time.Sleep(time.Duration(100-x) * 10 * time.Microsecond)
+		return x * x
+	}).Collect()
+
+	expected := itertools.Range(0, 100).Map(func(x int) int { return x * x }).Collect()
+	assert.Equal(t, expected, result)
+}
+
+func TestParallelMap_Unordered(t *testing.T) {
+	iter := itertools.Range(0, 50)
+	result := itertools.ParallelMap(iter, 4, func(x int) int { return x * 2 }, itertools.WithUnordered()).Collect()
+
+	slices.Sort(result)
+	expected := itertools.Range(0, 50).Map(func(x int) int { return x * 2 }).Collect()
+	assert.Equal(t, expected, result)
+}
+
+func TestParallelMap_UsesWorkers(t *testing.T) {
+	var running, peak atomic.Int32
+	itertools.ParallelMap(itertools.Range(0, 40), 4, func(x int) int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		running.Add(-1)
+		return x
+	}).Collect()
+
+	assert.Greater(t, peak.Load(), int32(1))
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+}
+
+func TestParallelMap_EarlyTermination(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var calls atomic.Int32
+	result := itertools.ParallelMap(itertools.Range(0, 1000000), 4, func(x int) int {
+		calls.Add(1)
+		return x
+	}).Take(5).Collect()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, result)
+
+	time.Sleep(100 * time.Millisecond)
+	runtime.GC()
+
+	after := runtime.NumGoroutine()
+	assert.LessOrEqual(t, after, before+1, "Goroutine leak detected")
+	assert.Less(t, calls.Load(), int32(100))
+}
+
+func TestParallelMap_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken})
+	iter := itertools.ParallelMap(lines, 2, func(s string) int { return len(s) })
+	result := iter.Collect()
+
+	assert.Equal(t, []int{1, 1}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestParallelMap_EarlyStopIdleInput(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+	defer close(ch)
+
+	returned := returnsWithin(time.Second, func() {
+		for v := range itertools.ParallelMap(itertools.FromChannel(ch), 2, func(x int) int { return x * 2 }).Seq() {
+			assert.Equal(t, 2, v)
+			break
+		}
+	})
+	assert.True(t, returned, "ParallelMap did not return while its input was idle")
+}
+
+func TestParallelMap_NoCallsAfterReturn(t *testing.T) {
+	var running atomic.Int32
+	slow := func(x int) int {
+		running.Add(1)
+		defer running.Add(-1)
+		time.Sleep(time.Millisecond)
+		return x
+	}
+
+	first := itertools.ParallelMap(itertools.Range(0, 1000), 8, slow).First()
+
+	assert.Equal(t, 0, first)
+	assert.Equal(t, int32(0), running.Load())
+}
+
+func TestParallelFilter(t *testing.T) {
+	iter := itertools.Range(0, 20)
+	result := itertools.ParallelFilter(iter, 3, func(x int) bool { return x%3 == 0 }).Collect()
+
+	assert.Equal(t, []int{0, 3, 6, 9, 12, 15, 18}, result)
+}
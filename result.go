@@ -0,0 +1,144 @@
+package itertools
+
+// Result holds either a value or the error produced while computing it.
+// It is the element type used by error-tolerant operators such as TryMap,
+// which let a pipeline carry per-element failures instead of panicking.
+//
+// Example:
+//
+//	r := itertools.Ok(42)
+//	v, err := r.Unwrap()
+//	// v is 42, err is nil
+type Result[V any] struct {
+	Value V
+	Err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[V any](v V) Result[V] {
+	return Result[V]{Value: v}
+}
+
+// Fail returns a failed Result holding err.
+func Fail[V any](err error) Result[V] {
+	return Result[V]{Err: err}
+}
+
+// IsOk returns true if the Result holds a value rather than an error.
+func (r Result[V]) IsOk() bool {
+	return r.Err == nil
+}
+
+// Unwrap returns the value and the error of the Result.
+func (r Result[V]) Unwrap() (V, error) {
+	return r.Value, r.Err
+}
+
+// TryMap transforms each element using a fallible function and yields a
+// Result for every element. Errors do not stop iteration; use CollectErr
+// to stop on the first error or PartitionErrors to separate them.
+//
+// TryMap is lazy - the function is applied only as elements are consumed.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"1", "x", "3"})
+//	results := itertools.TryMap(iter, strconv.Atoi).Collect()
+//	// results[0].Value is 1, results[1].Err is a *strconv.NumError
+func TryMap[V, U any](it *Iterator[V], f func(V) (U, error)) *Iterator[Result[U]] {
+	return &Iterator[Result[U]]{
+		seq: func(yield func(Result[U]) bool) {
+			it.seq(func(v V) bool {
+				u, err := f(v)
+				return yield(Result[U]{Value: u, Err: err})
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// MapErr transforms the error of every failed Result using the provided
+// function, leaving successful Results unchanged. It is useful for adding
+// context to errors produced earlier in the pipeline.
+//
+// Example:
+//
+//	results := itertools.TryMap(iter, strconv.Atoi)
+//	wrapped := itertools.MapErr(results, func(err error) error {
+//	    return fmt.Errorf("parse amount: %w", err)
+//	})
+func MapErr[V any](it *Iterator[Result[V]], f func(error) error) *Iterator[Result[V]] {
+	return &Iterator[Result[V]]{
+		seq: func(yield func(Result[V]) bool) {
+			it.seq(func(r Result[V]) bool {
+				if r.Err != nil {
+					r.Err = f(r.Err)
+				}
+				return yield(r)
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// CollectErr consumes an iterator of Results and returns the values as a slice.
+// It stops at the first failed Result and returns the values collected so far
+// together with that error. If the underlying source stops with an error,
+// that error is returned as well.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"1", "2", "x", "4"})
+//	nums, err := itertools.CollectErr(itertools.TryMap(iter, strconv.Atoi))
+//	// nums is []int{1, 2}, err is the error for "x"
+func CollectErr[V any](it *Iterator[Result[V]]) ([]V, error) {
+	collect := make([]V, 0)
+	var err error
+	it.seq(func(r Result[V]) bool {
+		if r.Err != nil {
+			err = r.Err
+			return false
+		}
+		collect = append(collect, r.Value)
+		return true
+	})
+	if err != nil {
+		return collect, err
+	}
+	return collect, it.Err()
+}
+
+// PartitionErrors splits an iterator of Results into two iterators: one with
+// the values of successful Results, and one with the errors of failed Results.
+//
+// Note: This method collects all elements into memory.
+//
+// Example:
+//
+//	iter := itertools.ToIter([]string{"1", "x", "3"})
+//	values, errs := itertools.PartitionErrors(itertools.TryMap(iter, strconv.Atoi))
+//	// values.Collect() is []int{1, 3}
+//	// errs.Collect() has one error
+func PartitionErrors[V any](it *Iterator[Result[V]]) (values *Iterator[V], errs *Iterator[error]) {
+	var ok []V
+	var failed []error
+
+	it.seq(func(r Result[V]) bool {
+		if r.Err != nil {
+			failed = append(failed, r.Err)
+		} else {
+			ok = append(ok, r.Value)
+		}
+		return true
+	})
+
+	values = ToIter(ok)
+	errs = ToIter(failed)
+	values.err = it.Err
+	values.closer = it.Close
+	errs.err = it.Err
+	errs.closer = it.Close
+	return
+}
@@ -0,0 +1,88 @@
+package itertools_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	ok := itertools.Ok(42)
+	assert.True(t, ok.IsOk())
+	v, err := ok.Unwrap()
+	assert.Equal(t, 42, v)
+	assert.NoError(t, err)
+
+	errBroken := errors.New("broken")
+	failed := itertools.Fail[int](errBroken)
+	assert.False(t, failed.IsOk())
+	_, err = failed.Unwrap()
+	assert.ErrorIs(t, err, errBroken)
+}
+
+func TestTryMap(t *testing.T) {
+	iter := itertools.ToIter([]string{"1", "x", "3"})
+	results := itertools.TryMap(iter, strconv.Atoi).Collect()
+
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, 1, results[0].Value)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Equal(t, 3, results[2].Value)
+}
+
+func TestMapErr(t *testing.T) {
+	iter := itertools.ToIter([]string{"1", "x"})
+	results := itertools.MapErr(itertools.TryMap(iter, strconv.Atoi), func(err error) error {
+		return fmt.Errorf("parse amount: %w", err)
+	}).Collect()
+
+	assert.NoError(t, results[0].Err)
+	assert.True(t, strings.HasPrefix(results[1].Err.Error(), "parse amount: "))
+
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(results[1].Err, &numErr))
+}
+
+func TestCollectErr(t *testing.T) {
+	iter := itertools.ToIter([]string{"1", "2", "3"})
+	nums, err := itertools.CollectErr(itertools.TryMap(iter, strconv.Atoi))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, nums)
+}
+
+func TestCollectErr_StopsOnFirstError(t *testing.T) {
+	calls := 0
+	iter := itertools.ToIter([]string{"1", "2", "x", "4", "y"})
+	nums, err := itertools.CollectErr(itertools.TryMap(iter, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	}))
+
+	assert.Equal(t, []int{1, 2}, nums)
+	assert.ErrorContains(t, err, `"x"`)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCollectErr_SourceError(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "1\n2\n", err: errBroken})
+	nums, err := itertools.CollectErr(itertools.TryMap(lines, strconv.Atoi))
+
+	assert.Equal(t, []int{1, 2}, nums)
+	assert.ErrorIs(t, err, errBroken)
+}
+
+func TestPartitionErrors(t *testing.T) {
+	iter := itertools.ToIter([]string{"1", "x", "3", "y"})
+	values, errs := itertools.PartitionErrors(itertools.TryMap(iter, strconv.Atoi))
+
+	assert.Equal(t, []int{1, 3}, values.Collect())
+	assert.Equal(t, 2, errs.Count())
+}
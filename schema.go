@@ -0,0 +1,314 @@
+package itertools
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CSVColumnType is the type of the values in a CSV column.
+type CSVColumnType int
+
+const (
+	// CSVTypeString accepts any value.
+	CSVTypeString CSVColumnType = iota
+	// CSVTypeInt accepts base 10 integers.
+	CSVTypeInt
+	// CSVTypeFloat accepts decimal numbers, including integers.
+	CSVTypeFloat
+	// CSVTypeBool accepts true and false, in any case.
+	CSVTypeBool
+	// CSVTypeDate accepts dates and times in the column's layout.
+	CSVTypeDate
+)
+
+func (t CSVColumnType) String() string {
+	switch t {
+	case CSVTypeInt:
+		return "int"
+	case CSVTypeFloat:
+		return "float"
+	case CSVTypeBool:
+		return "bool"
+	case CSVTypeDate:
+		return "date"
+	}
+	return "string"
+}
+
+// csvDateLayouts are the layouts tried, in order, when inferring date
+// columns.
+var csvDateLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+// CSVColumn describes one column of a CSVSchema.
+type CSVColumn struct {
+	Name string
+	Type CSVColumnType
+	// Layout is the time layout of a CSVTypeDate column.
+	Layout string
+	// Nullable allows empty values.
+	Nullable bool
+	// Distinct is the number of distinct non-empty values in the sample the
+	// column was inferred from, an estimate of its cardinality. It is not
+	// used for validation.
+	Distinct int
+}
+
+// CSVSchema describes the columns expected in a CSV file. It can be declared
+// directly or inferred with InferCSVSchema.
+type CSVSchema struct {
+	Columns []CSVColumn
+}
+
+// Column returns the column with the given name, and false if the schema has
+// no such column.
+func (s CSVSchema) Column(name string) (CSVColumn, bool) {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return CSVColumn{}, false
+}
+
+// InferCSVSchema reads up to n rows from the iterator and infers the type,
+// nullability and cardinality of each column in headers. A column is given
+// the narrowest type that accepts every non-empty value in the sample, in the
+// order int, float, bool, date, then string; dates are recognised in the
+// time.RFC3339, time.DateTime and time.DateOnly layouts.
+//
+// It returns the schema and an iterator that yields the sampled rows followed
+// by the rest of the input, so no row is lost. Errors from the input are
+// reported by the returned iterator's Err.
+//
+// Example:
+//
+//	rows, headers, _ := itertools.FromCSVWithHeaders(csv.NewReader(file))
+//	schema, rows := itertools.InferCSVSchema(rows, headers, 1000)
+//	for _, c := range schema.Columns {
+//	    fmt.Println(c.Name, c.Type, c.Nullable, c.Distinct)
+//	}
+func InferCSVSchema(it *Iterator[CSVRow], headers []string, n int) (CSVSchema, *Iterator[CSVRow]) {
+	var sample []CSVRow
+	for len(sample) < n && it.Next() {
+		sample = append(sample, it.Current())
+	}
+
+	schema := CSVSchema{Columns: make([]CSVColumn, len(headers))}
+	for i, name := range headers {
+		schema.Columns[i] = inferCSVColumn(name, i, sample)
+	}
+
+	replay := &Iterator[CSVRow]{
+		seq: func(yield func(CSVRow) bool) {
+			for _, row := range sample {
+				if !yield(row) {
+					return
+				}
+			}
+			for it.Next() {
+				if !yield(it.Current()) {
+					return
+				}
+			}
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+	return schema, replay
+}
+
+// inferCSVColumn infers the column at index i from the sampled rows.
+func inferCSVColumn(name string, i int, sample []CSVRow) CSVColumn {
+	col := CSVColumn{Name: name}
+	isInt, isFloat, isBool := true, true, true
+	layouts := csvDateLayouts
+	distinct := make(map[string]struct{})
+
+	for _, row := range sample {
+		v := row.Get(i)
+		if v == "" {
+			col.Nullable = true
+			continue
+		}
+		distinct[v] = struct{}{}
+		isInt = isInt && csvValueIs(CSVTypeInt, "", v)
+		isFloat = isFloat && csvValueIs(CSVTypeFloat, "", v)
+		isBool = isBool && csvValueIs(CSVTypeBool, "", v)
+		var matching []string
+		for _, layout := range layouts {
+			if csvValueIs(CSVTypeDate, layout, v) {
+				matching = append(matching, layout)
+			}
+		}
+		layouts = matching
+	}
+	col.Distinct = len(distinct)
+
+	switch {
+	case col.Distinct == 0:
+		col.Type = CSVTypeString
+	case isInt:
+		col.Type = CSVTypeInt
+	case isFloat:
+		col.Type = CSVTypeFloat
+	case isBool:
+		col.Type = CSVTypeBool
+	case len(layouts) > 0:
+		col.Type = CSVTypeDate
+		col.Layout = layouts[0]
+	default:
+		col.Type = CSVTypeString
+	}
+	return col
+}
+
+// csvValueIs reports whether the non-empty value v is valid for type t.
+func csvValueIs(t CSVColumnType, layout, v string) bool {
+	switch t {
+	case CSVTypeInt:
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	case CSVTypeFloat:
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	case CSVTypeBool:
+		switch v {
+		case "true", "True", "TRUE", "false", "False", "FALSE":
+			return true
+		}
+		return false
+	case CSVTypeDate:
+		_, err := time.Parse(layout, v)
+		return err == nil
+	}
+	return true
+}
+
+// CSVViolationKind classifies a CSVViolation.
+type CSVViolationKind int
+
+const (
+	// CSVMissingColumn reports a schema column that is not in the header.
+	CSVMissingColumn CSVViolationKind = iota
+	// CSVUnexpectedColumn reports a header column that is not in the schema.
+	CSVUnexpectedColumn
+	// CSVNullValue reports an empty value in a column that is not nullable.
+	CSVNullValue
+	// CSVTypeMismatch reports a value that does not match the column's type.
+	CSVTypeMismatch
+)
+
+// CSVViolation reports a difference between a CSV file and a schema. Row is
+// the index of the offending row, as in CSVRow.Index, or -1 for a difference
+// in the header.
+type CSVViolation struct {
+	Kind   CSVViolationKind
+	Row    int
+	Column string
+	Value  string
+	// Expected is the type the column should have, for CSVTypeMismatch.
+	Expected CSVColumnType
+}
+
+func (v CSVViolation) Error() string {
+	switch v.Kind {
+	case CSVMissingColumn:
+		return fmt.Sprintf("itertools: csv column %q is missing", v.Column)
+	case CSVUnexpectedColumn:
+		return fmt.Sprintf("itertools: csv column %q is not in the schema", v.Column)
+	case CSVNullValue:
+		return fmt.Sprintf("itertools: csv row %d, column %q: empty value", v.Row, v.Column)
+	}
+	return fmt.Sprintf("itertools: csv row %d, column %q: %q is not of type %s", v.Row, v.Column, v.Value, v.Expected)
+}
+
+// Check returns the violations of the schema in a single row. Columns that
+// are not in the row's header are ignored; a row without a header is checked
+// by position. Values missing from a short row are treated as empty.
+//
+// Example:
+//
+//	valid := rows.Filter(func(row itertools.CSVRow) bool {
+//	    return len(schema.Check(row)) == 0
+//	})
+func (s CSVSchema) Check(row CSVRow) []CSVViolation {
+	var violations []CSVViolation
+	for i, col := range s.Columns {
+		index := i
+		if row.Header != nil {
+			var ok bool
+			if index, ok = row.Header.Index(col.Name); !ok {
+				continue
+			}
+		}
+		v := row.Get(index)
+		switch {
+		case v == "":
+			if !col.Nullable {
+				violations = append(violations, CSVViolation{Kind: CSVNullValue, Row: row.Index, Column: col.Name})
+			}
+		case !csvValueIs(col.Type, col.Layout, v):
+			violations = append(violations, CSVViolation{
+				Kind:     CSVTypeMismatch,
+				Row:      row.Index,
+				Column:   col.Name,
+				Value:    v,
+				Expected: col.Type,
+			})
+		}
+	}
+	return violations
+}
+
+// ValidateCSV checks headers and then every row against the schema, and
+// returns an iterator over the violations found. Differences in the header,
+// such as missing or unexpected columns, are reported first, even if the
+// file has no data rows. With nil headers only the rows are checked.
+//
+// Example:
+//
+//	rows, headers, _ := itertools.FromCSVWithHeaders(csv.NewReader(partnerFile))
+//	for v := range itertools.ValidateCSV(rows, headers, contract).Take(100).Seq() {
+//	    log.Println(v)
+//	}
+func ValidateCSV(it *Iterator[CSVRow], headers []string, schema CSVSchema) *Iterator[CSVViolation] {
+	return &Iterator[CSVViolation]{
+		seq: func(yield func(CSVViolation) bool) {
+			if headers != nil {
+				for _, v := range schema.checkHeader(headers) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+			it.seq(func(row CSVRow) bool {
+				for _, v := range schema.Check(row) {
+					if !yield(v) {
+						return false
+					}
+				}
+				return true
+			})
+		},
+		err:    it.Err,
+		closer: it.Close,
+	}
+}
+
+// checkHeader returns the columns missing from or unexpected in headers.
+func (s CSVSchema) checkHeader(headers []string) []CSVViolation {
+	var violations []CSVViolation
+	for _, col := range s.Columns {
+		if !slices.Contains(headers, col.Name) {
+			violations = append(violations, CSVViolation{Kind: CSVMissingColumn, Row: -1, Column: col.Name})
+		}
+	}
+	for _, name := range headers {
+		if _, ok := s.Column(name); !ok {
+			violations = append(violations, CSVViolation{Kind: CSVUnexpectedColumn, Row: -1, Column: name})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,152 @@
+package itertools_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+const partnerCSV = `id,price,active,shipped,note,updated
+1,9.5,true,2024-03-01,,2024-03-01T10:00:00Z
+2,10,false,2024-03-02,fragile,2024-03-02T11:30:00Z
+3,12.25,TRUE,,fragile,2024-03-03T09:15:00Z
+4,7,False,2024-03-04,,2024-03-04T08:00:00Z`
+
+func TestInferCSVSchema(t *testing.T) {
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(partnerCSV)))
+	assert.NoError(t, err)
+
+	schema, rows := itertools.InferCSVSchema(rows, headers, 10)
+
+	assert.Equal(t, []itertools.CSVColumn{
+		{Name: "id", Type: itertools.CSVTypeInt, Distinct: 4},
+		{Name: "price", Type: itertools.CSVTypeFloat, Distinct: 4},
+		{Name: "active", Type: itertools.CSVTypeBool, Distinct: 4},
+		{Name: "shipped", Type: itertools.CSVTypeDate, Layout: time.DateOnly, Nullable: true, Distinct: 3},
+		{Name: "note", Type: itertools.CSVTypeString, Nullable: true, Distinct: 1},
+		{Name: "updated", Type: itertools.CSVTypeDate, Layout: time.RFC3339, Distinct: 4},
+	}, schema.Columns)
+
+	// The sampled rows are replayed
+	assert.Equal(t, 4, rows.Count())
+	assert.NoError(t, rows.Err())
+}
+
+func TestInferCSVSchema_SampleThenRest(t *testing.T) {
+	csvData := "n\n1\n2\nthree\n4\n"
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	schema, rows := itertools.InferCSVSchema(rows, headers, 2)
+
+	col, ok := schema.Column("n")
+	assert.True(t, ok)
+	assert.Equal(t, itertools.CSVTypeInt, col.Type)
+
+	values := itertools.MapTo(rows, func(row itertools.CSVRow) string { return row.Get(0) }).Collect()
+	assert.Equal(t, []string{"1", "2", "three", "4"}, values)
+
+	_, ok = schema.Column("missing")
+	assert.False(t, ok)
+}
+
+func TestInferCSVSchema_EmptyColumn(t *testing.T) {
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader("a,b\n1,\n2,\n")))
+	assert.NoError(t, err)
+
+	schema, _ := itertools.InferCSVSchema(rows, headers, 10)
+
+	assert.Equal(t, itertools.CSVColumn{Name: "b", Type: itertools.CSVTypeString, Nullable: true}, schema.Columns[1])
+}
+
+func TestValidateCSV(t *testing.T) {
+	schema := itertools.CSVSchema{Columns: []itertools.CSVColumn{
+		{Name: "id", Type: itertools.CSVTypeInt},
+		{Name: "price", Type: itertools.CSVTypeFloat},
+		{Name: "shipped", Type: itertools.CSVTypeDate, Layout: time.DateOnly, Nullable: true},
+		{Name: "currency", Type: itertools.CSVTypeString},
+	}}
+	csvData := `id,price,shipped,extra
+1,9.5,2024-03-01,x
+two,,03/02/2024,y
+3,1e3,,z`
+
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(csvData)))
+	assert.NoError(t, err)
+
+	violations := itertools.ValidateCSV(rows, headers, schema).Collect()
+
+	assert.Equal(t, []itertools.CSVViolation{
+		{Kind: itertools.CSVMissingColumn, Row: -1, Column: "currency"},
+		{Kind: itertools.CSVUnexpectedColumn, Row: -1, Column: "extra"},
+		{Kind: itertools.CSVTypeMismatch, Row: 1, Column: "id", Value: "two", Expected: itertools.CSVTypeInt},
+		{Kind: itertools.CSVNullValue, Row: 1, Column: "price"},
+		{Kind: itertools.CSVTypeMismatch, Row: 1, Column: "shipped", Value: "03/02/2024", Expected: itertools.CSVTypeDate},
+	}, violations)
+
+	assert.Equal(t, `itertools: csv row 1, column "id": "two" is not of type int`, violations[2].Error())
+	assert.Equal(t, `itertools: csv column "currency" is missing`, violations[0].Error())
+}
+
+func TestValidateCSV_HeaderOnly(t *testing.T) {
+	schema := itertools.CSVSchema{Columns: []itertools.CSVColumn{
+		{Name: "id", Type: itertools.CSVTypeInt},
+		{Name: "amount", Type: itertools.CSVTypeFloat},
+	}}
+
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader("id,total\n")))
+	assert.NoError(t, err)
+
+	violations := itertools.ValidateCSV(rows, headers, schema).Collect()
+
+	assert.Equal(t, []itertools.CSVViolation{
+		{Kind: itertools.CSVMissingColumn, Row: -1, Column: "amount"},
+		{Kind: itertools.CSVUnexpectedColumn, Row: -1, Column: "total"},
+	}, violations)
+}
+
+func TestValidateCSV_InferredSchemaCatchesDrift(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("id,amount\n")
+	for i := 0; i < 100; i++ {
+		sb.WriteString("1,2.5\n")
+	}
+	sb.WriteString("1,N/A\n")
+
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(strings.NewReader(sb.String())))
+	assert.NoError(t, err)
+
+	schema, rows := itertools.InferCSVSchema(rows, headers, 50)
+	violations := itertools.ValidateCSV(rows, headers, schema).Collect()
+
+	assert.Equal(t, 1, len(violations))
+	assert.Equal(t, 100, violations[0].Row)
+	assert.Equal(t, "N/A", violations[0].Value)
+}
+
+func TestValidateCSV_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	rows, headers, err := itertools.FromCSVWithHeaders(csv.NewReader(&errReader{data: "a\n1\n", err: errBroken}))
+	assert.NoError(t, err)
+
+	schema, rows := itertools.InferCSVSchema(rows, headers, 10)
+	violations := itertools.ValidateCSV(rows, headers, schema)
+
+	assert.Empty(t, violations.Collect())
+	assert.ErrorIs(t, violations.Err(), errBroken)
+}
+
+func TestCSVSchema_Check(t *testing.T) {
+	schema := itertools.CSVSchema{Columns: []itertools.CSVColumn{
+		{Name: "ok", Type: itertools.CSVTypeBool},
+	}}
+
+	assert.Empty(t, schema.Check(itertools.CSVRow{Fields: []string{"true"}}))
+	assert.Equal(t, 1, len(schema.Check(itertools.CSVRow{Fields: []string{"yes"}})))
+	assert.Equal(t, 1, len(schema.Check(itertools.CSVRow{})))
+}
@@ -0,0 +1,288 @@
+package itertools
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// MergeSorted lazily merges iterators that are each sorted according to the
+// less function into a single sorted iterator. It uses a heap, so it holds
+// only the current element of each input in memory. Elements that compare
+// equal keep the order of the inputs they came from.
+//
+// Iteration stops when one of the inputs stops with an error; Err reports
+// the first error.
+//
+// Example:
+//
+//	monday := itertools.ToIter([]int{1, 4, 9})
+//	tuesday := itertools.ToIter([]int{2, 3, 10})
+//	wednesday := itertools.ToIter([]int{4, 5})
+//	merged := itertools.MergeSorted(func(a, b int) bool { return a < b },
+//	    monday, tuesday, wednesday).Collect()
+//	// merged is []int{1, 2, 3, 4, 4, 5, 9, 10}
+func MergeSorted[V any](less func(a, b V) bool, its ...*Iterator[V]) *Iterator[V] {
+	return mergeSortedIters(less, false, its)
+}
+
+// MergeSortedDistinct is like MergeSorted, but yields only the first of each
+// run of elements that compare equal, dropping duplicates both within and
+// across inputs.
+//
+// Example:
+//
+//	a := itertools.ToIter([]int{1, 2, 2, 5})
+//	b := itertools.ToIter([]int{2, 3, 5})
+//	merged := itertools.MergeSortedDistinct(func(x, y int) bool { return x < y }, a, b).Collect()
+//	// merged is []int{1, 2, 3, 5}
+func MergeSortedDistinct[V any](less func(a, b V) bool, its ...*Iterator[V]) *Iterator[V] {
+	return mergeSortedIters(less, true, its)
+}
+
+func mergeSortedIters[V any](less func(a, b V) bool, distinct bool, its []*Iterator[V]) *Iterator[V] {
+	errs := make([]func() error, len(its))
+	closers := make([]func() error, len(its))
+	for i, it := range its {
+		errs[i] = it.Err
+		closers[i] = it.Close
+	}
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			failed := false
+			sources := make([]func() (V, bool), len(its))
+			for i, it := range its {
+				next, stop := iter.Pull(it.seq)
+				defer stop()
+				sources[i] = func() (V, bool) {
+					v, ok := next()
+					if !ok && it.Err() != nil {
+						failed = true
+					}
+					return v, ok
+				}
+			}
+
+			var last V
+			started := false
+			mergeSorted(sources, less, func(v V) bool {
+				if failed {
+					return false
+				}
+				if distinct {
+					if started && !less(last, v) {
+						return true
+					}
+					last, started = v, true
+				}
+				return yield(v)
+			})
+		},
+		err:    firstErr(errs...),
+		closer: closeAll(closers...),
+	}
+}
+
+// SortedSetOption configures the sorted set operations SortedUnion,
+// SortedIntersection, SortedDifference and SymmetricDifference.
+type SortedSetOption func(*sortedSetConfig)
+
+type sortedSetConfig struct {
+	multiset bool
+}
+
+// WithMultiset makes the sorted set operations treat their inputs as
+// multisets (bags), where repeated elements count. An element that occurs m
+// times in the first input and n times in the second occurs max(m, n) times
+// in the union, min(m, n) times in the intersection, m-n times in the
+// difference and |m-n| times in the symmetric difference.
+func WithMultiset() SortedSetOption {
+	return func(c *sortedSetConfig) {
+		c.multiset = true
+	}
+}
+
+// SortedUnion returns an iterator over the elements of either iterator, for
+// inputs that are both sorted according to the less function. Unlike Union,
+// it streams both inputs in constant memory and keeps the output sorted.
+// Elements that compare equal are yielded once, taken from the first
+// iterator; use WithMultiset to keep repeated elements.
+//
+// Example:
+//
+//	a := itertools.ToIter([]int{1, 2, 2, 4})
+//	b := itertools.ToIter([]int{2, 3, 4})
+//	union := a.SortedUnion(b, func(x, y int) bool { return x < y }).Collect()
+//	// union is []int{1, 2, 3, 4}
+func (it *Iterator[V]) SortedUnion(other *Iterator[V], less func(a, b V) bool, opts ...SortedSetOption) *Iterator[V] {
+	return sortedSetOp(it, other, less, opts, true, true, true)
+}
+
+// SortedIntersection returns an iterator over the elements of this iterator
+// that are also in the other, for inputs that are both sorted according to
+// the less function. It streams both inputs in constant memory and stops as
+// soon as either input is exhausted.
+//
+// Example:
+//
+//	a := itertools.ToIter([]int{1, 2, 2, 4})
+//	b := itertools.ToIter([]int{2, 2, 3, 4})
+//	both := a.SortedIntersection(b, func(x, y int) bool { return x < y },
+//	    itertools.WithMultiset()).Collect()
+//	// both is []int{2, 2, 4}
+func (it *Iterator[V]) SortedIntersection(other *Iterator[V], less func(a, b V) bool, opts ...SortedSetOption) *Iterator[V] {
+	return sortedSetOp(it, other, less, opts, false, false, true)
+}
+
+// SortedDifference returns an iterator over the elements of this iterator
+// that are not in the other, for inputs that are both sorted according to
+// the less function. It streams both inputs in constant memory.
+//
+// Example:
+//
+//	expected := itertools.ToIter([]int{1, 2, 3, 4})
+//	received := itertools.ToIter([]int{2, 4})
+//	missing := expected.SortedDifference(received, func(x, y int) bool { return x < y }).Collect()
+//	// missing is []int{1, 3}
+func (it *Iterator[V]) SortedDifference(other *Iterator[V], less func(a, b V) bool, opts ...SortedSetOption) *Iterator[V] {
+	return sortedSetOp(it, other, less, opts, true, false, false)
+}
+
+// SymmetricDifference returns an iterator over the elements that are in
+// exactly one of the iterators, for inputs that are both sorted according to
+// the less function. It streams both inputs in constant memory and keeps the
+// output sorted.
+//
+// Example:
+//
+//	a := itertools.ToIter([]int{1, 2, 3})
+//	b := itertools.ToIter([]int{2, 3, 4})
+//	diff := a.SymmetricDifference(b, func(x, y int) bool { return x < y }).Collect()
+//	// diff is []int{1, 4}
+func (it *Iterator[V]) SymmetricDifference(other *Iterator[V], less func(a, b V) bool, opts ...SortedSetOption) *Iterator[V] {
+	return sortedSetOp(it, other, less, opts, true, true, false)
+}
+
+// sortedSetOp walks two sorted inputs in step, pairing up elements that
+// compare equal, and yields the elements found only in it, only in other, or
+// in both, as selected. Pairing elements one by one gives multiset semantics;
+// skipping repeats within each input gives set semantics.
+func sortedSetOp[V any](it, other *Iterator[V], less func(a, b V) bool, opts []SortedSetOption, onlyLeft, onlyRight, both bool) *Iterator[V] {
+	var cfg sortedSetConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			nextA, stopA := iter.Pull(it.seq)
+			defer stopA()
+			nextB, stopB := iter.Pull(other.seq)
+			defer stopB()
+
+			// pull returns the next element of a side, skipping repeats of the
+			// previous one unless in multiset mode
+			pull := func(next func() (V, bool), prev V, started bool) (V, bool) {
+				for {
+					v, ok := next()
+					if !ok || cfg.multiset || !started || less(prev, v) {
+						return v, ok
+					}
+				}
+			}
+
+			var zero V
+			a, okA := pull(nextA, zero, false)
+			b, okB := pull(nextB, zero, false)
+			for {
+				// Stop once an input has failed, or the other input has
+				// nothing left that could be yielded
+				if !okA && (!okB || !onlyRight || it.Err() != nil) {
+					return
+				}
+				if !okB && (!onlyLeft || other.Err() != nil) {
+					return
+				}
+				switch {
+				case !okB || (okA && less(a, b)):
+					if onlyLeft && !yield(a) {
+						return
+					}
+					a, okA = pull(nextA, a, true)
+				case !okA || less(b, a):
+					if onlyRight && !yield(b) {
+						return
+					}
+					b, okB = pull(nextB, b, true)
+				default:
+					if both && !yield(a) {
+						return
+					}
+					a, okA = pull(nextA, a, true)
+					b, okB = pull(nextB, b, true)
+				}
+			}
+		},
+		err:    firstErr(it.Err, other.Err),
+		closer: closeAll(it.Close, other.Close),
+	}
+}
+
+// mergeSorted merges the sorted sources into one sorted sequence passed to
+// yield. Ties are broken by source order, so the merge is stable. It stops
+// when every source is exhausted or yield returns false.
+func mergeSorted[V any](sources []func() (V, bool), less func(a, b V) bool, yield func(V) bool) {
+	h := &mergeHeap[V]{less: less}
+	for i, next := range sources {
+		if v, ok := next(); ok {
+			h.items = append(h.items, mergeItem[V]{v: v, source: i})
+		}
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		top := h.items[0]
+		if !yield(top.v) {
+			return
+		}
+		if v, ok := sources[top.source](); ok {
+			h.items[0].v = v
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+}
+
+type mergeItem[V any] struct {
+	v      V
+	source int
+}
+
+// mergeHeap is a min-heap of the current element of each source.
+type mergeHeap[V any] struct {
+	items []mergeItem[V]
+	less  func(a, b V) bool
+}
+
+func (h *mergeHeap[V]) Len() int { return len(h.items) }
+
+func (h *mergeHeap[V]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.less(a.v, b.v) {
+		return true
+	}
+	if h.less(b.v, a.v) {
+		return false
+	}
+	return a.source < b.source
+}
+
+func (h *mergeHeap[V]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *mergeHeap[V]) Push(x any) { h.items = append(h.items, x.(mergeItem[V])) }
+
+func (h *mergeHeap[V]) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items = h.items[:n]
+	return item
+}
@@ -0,0 +1,200 @@
+package itertools_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSorted(t *testing.T) {
+	a := itertools.ToIter([]int{1, 4, 9})
+	b := itertools.ToIter([]int{2, 3, 10})
+	c := itertools.ToIter([]int{4, 5})
+	empty := itertools.ToIter([]int{})
+
+	result := itertools.MergeSorted(intLess, a, empty, b, c).Collect()
+
+	assert.Equal(t, []int{1, 2, 3, 4, 4, 5, 9, 10}, result)
+}
+
+func TestMergeSorted_StableAcrossInputs(t *testing.T) {
+	type rec struct {
+		Key  int
+		From string
+	}
+	a := itertools.ToIter([]rec{{1, "a"}, {2, "a"}})
+	b := itertools.ToIter([]rec{{1, "b"}, {2, "b"}})
+
+	result := itertools.MergeSorted(func(x, y rec) bool { return x.Key < y.Key }, a, b).Collect()
+
+	assert.Equal(t, []rec{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, result)
+}
+
+func TestMergeSorted_NoInputs(t *testing.T) {
+	assert.Empty(t, itertools.MergeSorted(intLess).Collect())
+}
+
+func TestMergeSorted_Lazy(t *testing.T) {
+	evens := itertools.Generate(func() func() int {
+		n := -2
+		return func() int { n += 2; return n }
+	}())
+	odds := itertools.Generate(func() func() int {
+		n := -1
+		return func() int { n += 2; return n }
+	}())
+
+	result := itertools.MergeSorted(intLess, evens, odds).Take(6).Collect()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, result)
+}
+
+func TestMergeSortedDistinct(t *testing.T) {
+	a := itertools.ToIter([]string{"apple", "fig", "fig", "pear"})
+	b := itertools.ToIter([]string{"banana", "fig", "pear"})
+	c := itertools.ToIter([]string{"apple", "kiwi"})
+
+	result := itertools.MergeSortedDistinct(func(x, y string) bool { return x < y }, a, b, c).Collect()
+
+	assert.Equal(t, []string{"apple", "banana", "fig", "kiwi", "pear"}, result)
+}
+
+func TestMergeSortedDistinct_CustomEquality(t *testing.T) {
+	// Words compare equal ignoring case, so only the first spelling is kept
+	lessFold := func(x, y string) bool { return strings.ToLower(x) < strings.ToLower(y) }
+	a := itertools.ToIter([]string{"Go", "rust"})
+	b := itertools.ToIter([]string{"go", "Rust", "zig"})
+
+	result := itertools.MergeSortedDistinct(lessFold, a, b).Collect()
+
+	assert.Equal(t, []string{"Go", "rust", "zig"}, result)
+}
+
+func TestMergeSorted_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	a := itertools.FromReader(&errReader{data: "b\nd\n", err: errBroken})
+	b := itertools.ToIter([]string{"a", "c", "e", "f"})
+
+	iter := itertools.MergeSorted(func(x, y string) bool { return x < y }, a, b)
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestMergeSorted_Close(t *testing.T) {
+	closed := 0
+	onClose := func() error { closed++; return nil }
+	a := itertools.Range(0, 5).OnClose(onClose)
+	b := itertools.Range(0, 5).OnClose(onClose)
+
+	iter := itertools.MergeSorted(intLess, a, b)
+	assert.True(t, iter.Next())
+	assert.NoError(t, iter.Close())
+	assert.Equal(t, 2, closed)
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	a := []int{1, 2, 2, 2, 4, 6}
+	b := []int{2, 2, 3, 4, 4, 7}
+
+	tests := []struct {
+		name     string
+		op       func(x, y *itertools.Iterator[int], opts ...itertools.SortedSetOption) *itertools.Iterator[int]
+		set      []int
+		multiset []int
+	}{
+		{
+			"union",
+			func(x, y *itertools.Iterator[int], opts ...itertools.SortedSetOption) *itertools.Iterator[int] {
+				return x.SortedUnion(y, intLess, opts...)
+			},
+			[]int{1, 2, 3, 4, 6, 7},
+			[]int{1, 2, 2, 2, 3, 4, 4, 6, 7},
+		},
+		{
+			"intersection",
+			func(x, y *itertools.Iterator[int], opts ...itertools.SortedSetOption) *itertools.Iterator[int] {
+				return x.SortedIntersection(y, intLess, opts...)
+			},
+			[]int{2, 4},
+			[]int{2, 2, 4},
+		},
+		{
+			"difference",
+			func(x, y *itertools.Iterator[int], opts ...itertools.SortedSetOption) *itertools.Iterator[int] {
+				return x.SortedDifference(y, intLess, opts...)
+			},
+			[]int{1, 6},
+			[]int{1, 2, 6},
+		},
+		{
+			"symmetric difference",
+			func(x, y *itertools.Iterator[int], opts ...itertools.SortedSetOption) *itertools.Iterator[int] {
+				return x.SymmetricDifference(y, intLess, opts...)
+			},
+			[]int{1, 3, 6, 7},
+			[]int{1, 2, 3, 4, 6, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := tt.op(itertools.ToIter(a), itertools.ToIter(b)).Collect()
+			assert.Equal(t, tt.set, set)
+
+			multiset := tt.op(itertools.ToIter(a), itertools.ToIter(b), itertools.WithMultiset()).Collect()
+			assert.Equal(t, tt.multiset, multiset)
+		})
+	}
+}
+
+func TestSortedSetOperations_Empty(t *testing.T) {
+	empty := func() *itertools.Iterator[int] { return itertools.ToIter([]int{}) }
+	some := func() *itertools.Iterator[int] { return itertools.ToIter([]int{1, 1, 2}) }
+
+	assert.Equal(t, []int{1, 2}, empty().SortedUnion(some(), intLess).Collect())
+	assert.Equal(t, []int{1, 2}, some().SortedUnion(empty(), intLess).Collect())
+	assert.Empty(t, some().SortedIntersection(empty(), intLess).Collect())
+	assert.Equal(t, []int{1, 2}, some().SortedDifference(empty(), intLess).Collect())
+	assert.Empty(t, empty().SortedDifference(some(), intLess).Collect())
+	assert.Equal(t, []int{1, 1, 2}, empty().SymmetricDifference(some(), intLess, itertools.WithMultiset()).Collect())
+}
+
+func TestSortedIntersection_StopsEarly(t *testing.T) {
+	pulled := 0
+	big := itertools.FromFunc(func() (int, bool) {
+		pulled++
+		return pulled, true
+	})
+	small := itertools.ToIter([]int{3, 5})
+
+	result := small.SortedIntersection(big, intLess).Collect()
+
+	assert.Equal(t, []int{3, 5}, result)
+	assert.Less(t, pulled, 10)
+}
+
+func TestSortedDifference_Reconciliation(t *testing.T) {
+	// Streams two long sorted ID lists without holding either in memory
+	expected := itertools.Range(0, 100000)
+	received := itertools.Range(0, 100000).Filter(func(id int) bool { return id%25000 != 7 })
+
+	missing := expected.SortedDifference(received, intLess).Collect()
+
+	assert.Equal(t, []int{7, 25007, 50007, 75007}, missing)
+}
+
+func TestSortedUnion_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	a := itertools.FromReader(&errReader{data: "b\nd\n", err: errBroken})
+	b := itertools.ToIter([]string{"a", "c", "e", "f"})
+
+	iter := a.SortedUnion(b, func(x, y string) bool { return x < y })
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"context"
 	"io"
+	"slices"
+	"sync"
 )
 
 // FromChannel creates a lazy Iterator from a channel.
@@ -35,6 +37,7 @@ func FromChannel[V any](ch <-chan V) *Iterator[V] {
 
 // FromChannelWithContext creates a lazy Iterator from a channel with context support.
 // The iterator will stop when either the channel is closed or the context is cancelled.
+// If the context is cancelled, Err returns the context's error.
 //
 // Example:
 //
@@ -43,11 +46,14 @@ func FromChannel[V any](ch <-chan V) *Iterator[V] {
 //	ch := make(chan int)
 //	iter := itertools.FromChannelWithContext(ctx, ch)
 func FromChannelWithContext[V any](ctx context.Context, ch <-chan V) *Iterator[V] {
+	var err error
 	return &Iterator[V]{
 		seq: func(yield func(V) bool) {
+			err = nil
 			for {
 				select {
 				case <-ctx.Done():
+					err = ctx.Err()
 					return
 				case v, ok := <-ch:
 					if !ok {
@@ -59,6 +65,7 @@ func FromChannelWithContext[V any](ctx context.Context, ch <-chan V) *Iterator[V
 				}
 			}
 		},
+		err: func() error { return err },
 	}
 }
 
@@ -66,6 +73,10 @@ func FromChannelWithContext[V any](ctx context.Context, ch <-chan V) *Iterator[V
 // Each element is a line from the reader (without the newline character).
 // This is useful for processing large files without loading them entirely into memory.
 //
+// Read errors, including lines longer than bufio.MaxScanTokenSize, stop
+// iteration and are reported by Err. The iterator does not close r; use
+// OnClose to close it together with the iterator.
+//
 // Example:
 //
 //	file, _ := os.Open("large_file.txt")
@@ -75,20 +86,14 @@ func FromChannelWithContext[V any](ctx context.Context, ch <-chan V) *Iterator[V
 //	    return strings.Contains(line, "ERROR")
 //	}).Count()
 func FromReader(r io.Reader) *Iterator[string] {
-	return &Iterator[string]{
-		seq: func(yield func(string) bool) {
-			scanner := bufio.NewScanner(r)
-			for scanner.Scan() {
-				if !yield(scanner.Text()) {
-					return
-				}
-			}
-		},
-	}
+	return FromReaderWithContext(context.Background(), r)
 }
 
 // FromReaderWithContext creates a lazy Iterator from an io.Reader with context support.
 // The iterator will stop when either the reader is exhausted or the context is cancelled.
+// Read errors and the context's error are reported by Err.
+// The iterator does not close r; use OnClose to close it together with the
+// iterator.
 //
 // Example:
 //
@@ -98,12 +103,15 @@ func FromReader(r io.Reader) *Iterator[string] {
 //	defer file.Close()
 //	iter := itertools.FromReaderWithContext(ctx, file)
 func FromReaderWithContext(ctx context.Context, r io.Reader) *Iterator[string] {
+	var err error
 	return &Iterator[string]{
 		seq: func(yield func(string) bool) {
+			err = nil
 			scanner := bufio.NewScanner(r)
 			for scanner.Scan() {
 				select {
 				case <-ctx.Done():
+					err = ctx.Err()
 					return
 				default:
 					if !yield(scanner.Text()) {
@@ -111,7 +119,9 @@ func FromReaderWithContext(ctx context.Context, r io.Reader) *Iterator[string] {
 					}
 				}
 			}
+			err = scanner.Err()
 		},
+		err: func() error { return err },
 	}
 }
 
@@ -149,6 +159,7 @@ func FromFunc[V any](fn func() (V, bool)) *Iterator[V] {
 
 // FromFuncWithContext creates a lazy Iterator from a generator function with context support.
 // The iterator will stop when either the function returns false or the context is cancelled.
+// If the context is cancelled, Err returns the context's error.
 //
 // Example:
 //
@@ -159,11 +170,14 @@ func FromFunc[V any](fn func() (V, bool)) *Iterator[V] {
 //	})
 //	result := iter.Take(100).Collect()
 func FromFuncWithContext[V any](ctx context.Context, fn func() (V, bool)) *Iterator[V] {
+	var err error
 	return &Iterator[V]{
 		seq: func(yield func(V) bool) {
+			err = nil
 			for {
 				select {
 				case <-ctx.Done():
+					err = ctx.Err()
 					return
 				default:
 					v, ok := fn()
@@ -176,6 +190,7 @@ func FromFuncWithContext[V any](ctx context.Context, fn func() (V, bool)) *Itera
 				}
 			}
 		},
+		err: func() error { return err },
 	}
 }
 
@@ -248,6 +263,7 @@ func Generate[V any](fn func() V) *Iterator[V] {
 }
 
 // GenerateWithContext creates an infinite Iterator with context support.
+// If the context is cancelled, Err returns the context's error.
 //
 // Example:
 //
@@ -258,11 +274,14 @@ func Generate[V any](fn func() V) *Iterator[V] {
 //	})
 //	result := iter.Take(1000).Collect()
 func GenerateWithContext[V any](ctx context.Context, fn func() V) *Iterator[V] {
+	var err error
 	return &Iterator[V]{
 		seq: func(yield func(V) bool) {
+			err = nil
 			for {
 				select {
 				case <-ctx.Done():
+					err = ctx.Err()
 					return
 				default:
 					if !yield(fn()) {
@@ -271,5 +290,349 @@ func GenerateWithContext[V any](ctx context.Context, fn func() V) *Iterator[V] {
 				}
 			}
 		},
+		err: func() error { return err },
+	}
+}
+
+// ToChannel runs the iterator in a new goroutine and sends its elements to
+// the returned channel, which has a buffer of bufSize elements. The channel
+// is closed when the iterator is exhausted or the context is cancelled.
+//
+// A consumer that stops reading before the channel is closed must cancel the
+// context so that the goroutine can exit. Once the channel is closed, Err
+// reports any error that stopped the iterator.
+//
+// ToChannel never closes the iterator; the caller owns Close. After the
+// context is cancelled, the goroutine exits once its pending read of the
+// iterator returns, which for an idle input such as FromChannel is when the
+// next element arrives or the input ends. Closing the iterator, or calling
+// anything that closes it such as First, before the channel is closed may
+// close the input while that read is in progress.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	for line := range itertools.FromReader(file).ToChannel(ctx, 64) {
+//	    fmt.Println(line)
+//	}
+func (it *Iterator[V]) ToChannel(ctx context.Context, bufSize int) <-chan V {
+	ch := make(chan V, bufSize)
+	go func() {
+		defer close(ch)
+		it.seq(func(v V) bool {
+			select {
+			case ch <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch
+}
+
+// Buffer returns an iterator that reads up to n elements ahead of the
+// consumer in a background goroutine. This lets a slow producer, such as
+// FromReader over a network stream, overlap with a slow consumer.
+//
+// The background goroutine is started when iteration begins. When the
+// consumer stops early, iteration returns at once and the goroutine stops
+// at its next element, as with ToChannel.
+//
+// Example:
+//
+//	lines := itertools.FromReader(conn).Buffer(1024)
+//	lines.Each(process) // reading continues while process runs
+func (it *Iterator[V]) Buffer(n int) *Iterator[V] {
+	return it.BufferWithContext(context.Background(), n)
+}
+
+// BufferWithContext is like Buffer, but also stops when the context is
+// cancelled. In that case Err returns the context's error.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	lines := itertools.FromReader(conn).BufferWithContext(ctx, 1024)
+func (it *Iterator[V]) BufferWithContext(ctx context.Context, n int) *Iterator[V] {
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			bufCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			ch := it.ToChannel(bufCtx, n)
+			for v := range ch {
+				if !yield(v) {
+					return
+				}
+			}
+			// The channel is closed, so the producer has finished
+			if err = ctx.Err(); err == nil {
+				err = it.Err()
+			}
+		},
+		err:    func() error { return err },
+		closer: it.Close,
+	}
+}
+
+// Merge reads all iterators concurrently, each in its own goroutine, and
+// yields their elements as they arrive. It is a fan-in for sources that
+// block, such as FromChannel or FromReader over several files or sockets.
+// Elements from the same input keep their relative order; there is no
+// ordering between inputs.
+//
+// The iterator stops when all inputs are exhausted, when the context is
+// cancelled, or when an input stops with an error. In the last two cases
+// the remaining inputs are cancelled and Err reports the error.
+//
+// Iteration returns only once every input has stopped reading, so the
+// inputs can be closed safely. An input blocked in a read that cannot be
+// interrupted, such as FromChannel on an idle channel, delays the return
+// until it yields an element or ends; FanIn merges channels without this
+// limitation.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	lines := itertools.Merge(ctx,
+//	    itertools.FromReader(appLog),
+//	    itertools.FromReader(accessLog),
+//	)
+//	errors := lines.Filter(func(l string) bool { return strings.Contains(l, "ERROR") })
+func Merge[V any](ctx context.Context, its ...*Iterator[V]) *Iterator[V] {
+	closers := make([]func() error, len(its))
+	for i, it := range its {
+		closers[i] = it.Close
+	}
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			mergeCtx, cancel := context.WithCancel(ctx)
+			// Join the inputs, so that nothing reads them after iteration
+			// returns
+			var wg sync.WaitGroup
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			// The inputs run on separate goroutines, so the first input
+			// error is handed over under a lock.
+			var mu sync.Mutex
+			var inputErr error
+			stopErr := func() error {
+				if e := ctx.Err(); e != nil {
+					return e
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				return inputErr
+			}
+
+			out := make(chan V)
+			wg.Add(len(its))
+			for _, it := range its {
+				go func() {
+					defer wg.Done()
+					it.seq(func(v V) bool {
+						select {
+						case out <- v:
+							return true
+						case <-mergeCtx.Done():
+							return false
+						}
+					})
+					if e := it.Err(); e != nil {
+						mu.Lock()
+						if inputErr == nil {
+							inputErr = e
+						}
+						mu.Unlock()
+						cancel()
+					}
+				}()
+			}
+			go func() {
+				wg.Wait()
+				close(out)
+			}()
+
+			for {
+				select {
+				case <-mergeCtx.Done():
+					err = stopErr()
+					return
+				case v, ok := <-out:
+					if !ok {
+						// The last input may have failed as it finished
+						err = stopErr()
+						return
+					}
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+		err:    func() error { return err },
+		closer: closeAll(closers...),
+	}
+}
+
+// MergeRoundRobin reads all iterators concurrently like Merge, but yields
+// their elements fairly: one element from each input in turn, skipping
+// inputs that are exhausted. A slow input delays the others, so no input
+// can starve the rest.
+//
+// The iterator stops when all inputs are exhausted, when the context is
+// cancelled, or when an input stops with an error, which Err reports.
+//
+// As with Merge, iteration returns only once every input has stopped
+// reading, so the inputs can be closed safely. An input blocked in a read
+// that cannot be interrupted, such as FromChannel on an idle channel,
+// delays the return until it yields an element or ends; FanIn merges
+// channels without this limitation.
+//
+// Example:
+//
+//	its := []*itertools.Iterator[int]{
+//	    itertools.ToIter([]int{1, 4, 7}),
+//	    itertools.ToIter([]int{2, 5}),
+//	    itertools.ToIter([]int{3}),
+//	}
+//	merged := itertools.MergeRoundRobin(context.Background(), its...).Collect()
+//	// merged is []int{1, 2, 3, 4, 5, 7}
+func MergeRoundRobin[V any](ctx context.Context, its ...*Iterator[V]) *Iterator[V] {
+	closers := make([]func() error, len(its))
+	for i, it := range its {
+		closers[i] = it.Close
+	}
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			mergeCtx, cancel := context.WithCancel(ctx)
+
+			type input struct {
+				it *Iterator[V]
+				ch <-chan V
+			}
+			inputs := make([]input, len(its))
+			for i, it := range its {
+				inputs[i] = input{it: it, ch: it.ToChannel(mergeCtx, 0)}
+			}
+			defer func() {
+				cancel()
+				// Wait for every input to stop reading
+				for _, in := range inputs {
+					for range in.ch {
+					}
+				}
+			}()
+
+			for len(inputs) > 0 {
+				for i := 0; i < len(inputs); {
+					select {
+					case <-ctx.Done():
+						err = ctx.Err()
+						return
+					case v, ok := <-inputs[i].ch:
+						if !ok {
+							// The channel is closed, so the input has finished
+							if err = inputs[i].it.Err(); err != nil {
+								return
+							}
+							inputs = slices.Delete(inputs, i, i+1)
+							continue
+						}
+						if !yield(v) {
+							return
+						}
+						i++
+					}
+				}
+			}
+		},
+		err:    func() error { return err },
+		closer: closeAll(closers...),
+	}
+}
+
+// FanIn merges several channels into one iterator, yielding elements as
+// they arrive on any channel. It stops when all channels are closed or the
+// context is cancelled, in which case Err returns the context's error. It
+// works like Merge over FromChannel, but stops waiting on idle channels as
+// soon as the consumer stops.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	events := itertools.FanIn(ctx, queueA, queueB, queueC)
+//	for events.Next() {
+//	    handle(events.Current())
+//	}
+func FanIn[V any](ctx context.Context, chans ...<-chan V) *Iterator[V] {
+	var err error
+	return &Iterator[V]{
+		seq: func(yield func(V) bool) {
+			err = nil
+			fanCtx, cancel := context.WithCancel(ctx)
+			var wg sync.WaitGroup
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			out := make(chan V)
+			wg.Add(len(chans))
+			for _, ch := range chans {
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-fanCtx.Done():
+							return
+						case v, ok := <-ch:
+							if !ok {
+								return
+							}
+							select {
+							case out <- v:
+							case <-fanCtx.Done():
+								return
+							}
+						}
+					}
+				}()
+			}
+			go func() {
+				wg.Wait()
+				close(out)
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					err = ctx.Err()
+					return
+				case v, ok := <-out:
+					if !ok {
+						err = ctx.Err()
+						return
+					}
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+		err: func() error { return err },
 	}
 }
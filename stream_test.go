@@ -1,8 +1,14 @@
 package itertools_test
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -304,3 +310,496 @@ func TestStreamWithNext(t *testing.T) {
 
 	assert.Equal(t, []int{1, 2, 3, 4, 5}, result)
 }
+
+// errReader returns its data followed by a read error.
+type errReader struct {
+	data string
+	err  error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestFromReader_Err(t *testing.T) {
+	errBroken := errors.New("connection reset")
+	iter := itertools.FromReader(&errReader{data: "line1\nline2\n", err: errBroken}).
+		Map(strings.ToUpper)
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"LINE1", "LINE2"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestFromReader_LineTooLong(t *testing.T) {
+	data := "short\n" + strings.Repeat("x", bufio.MaxScanTokenSize+1) + "\nafter\n"
+	iter := itertools.FromReader(strings.NewReader(data))
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"short"}, result)
+	assert.ErrorIs(t, iter.Err(), bufio.ErrTooLong)
+}
+
+func TestFromReader_NoErr(t *testing.T) {
+	iter := itertools.FromReader(strings.NewReader("a\nb"))
+	iter.Collect()
+
+	assert.NoError(t, iter.Err())
+}
+
+func TestFromChannelWithContext_Err(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter := itertools.FromChannelWithContext(ctx, make(chan int))
+	iter.Collect()
+
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestErr_Chain(t *testing.T) {
+	errBroken := errors.New("broken")
+	failing := itertools.FromReader(&errReader{data: "a\n", err: errBroken})
+	iter := failing.Chain(itertools.ToIter([]string{"b"}))
+	result := iter.Collect()
+
+	// The second iterator is not consumed after the first one fails
+	assert.Equal(t, []string{"a"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestErr_Zip(t *testing.T) {
+	errBroken := errors.New("broken")
+	lines := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken})
+	zipped := itertools.Zip(itertools.Range(0, 10), lines)
+	result := zipped.Collect()
+
+	assert.Equal(t, 2, len(result))
+	assert.ErrorIs(t, zipped.Err(), errBroken)
+}
+
+func TestErr_FlatMap(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.FlatMap(itertools.ToIter([]string{"a\n", "b\n"}), func(s string) *itertools.Iterator[string] {
+		return itertools.FromReader(&errReader{data: s, err: errBroken})
+	})
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"a"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+// closeTracker is an io.ReadCloser that records whether it was closed.
+type closeTracker struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+// slowReader is an endless io.ReadCloser that yields one line per Read,
+// after a short delay, and records reads made after Close.
+type slowReader struct {
+	mu             sync.Mutex
+	closed         bool
+	readAfterClose bool
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(time.Millisecond)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		r.readAfterClose = true
+		return 0, errors.New("read after close")
+	}
+	return copy(p, "line\n"), nil
+}
+
+func (r *slowReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return nil
+}
+
+// ReadAfterClose waits for stray readers to show up and reports whether any
+// read happened after Close.
+func (r *slowReader) ReadAfterClose() bool {
+	time.Sleep(20 * time.Millisecond)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readAfterClose
+}
+
+// returnsWithin reports whether fn returns within d.
+func returnsWithin(d time.Duration, fn func()) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+func TestFromReader_DoesNotCloseReader(t *testing.T) {
+	rc := &closeTracker{Reader: strings.NewReader("line1\nline2\nline3\n")}
+	iter := itertools.FromReader(rc)
+
+	assert.Equal(t, "line2", iter.Nth(1))
+	assert.False(t, rc.closed)
+}
+
+func TestFromReader_OnClose(t *testing.T) {
+	rc := &closeTracker{Reader: strings.NewReader("line1\nline2\nline3\n")}
+	iter := itertools.FromReader(rc).OnClose(rc.Close)
+
+	assert.Equal(t, "line2", iter.Nth(1))
+	assert.True(t, rc.closed)
+}
+
+func TestFromReader_DeferClose(t *testing.T) {
+	rc := &closeTracker{Reader: strings.NewReader("a\nSTOP\nb\n")}
+
+	func() {
+		iter := itertools.FromReader(rc).OnClose(rc.Close).Map(strings.ToUpper)
+		defer iter.Close()
+		for iter.Next() {
+			if iter.Current() == "STOP" {
+				break
+			}
+		}
+	}()
+
+	assert.True(t, rc.closed)
+}
+
+func TestToChannel(t *testing.T) {
+	ch := itertools.Range(0, 5).ToChannel(context.Background(), 2)
+
+	var result []int
+	for v := range ch {
+		result = append(result, v)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, result)
+}
+
+func TestToChannel_Cancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := itertools.Range(0, 1000000).ToChannel(ctx, 0)
+	assert.Equal(t, 0, <-ch)
+	assert.Equal(t, 1, <-ch)
+	cancel()
+
+	// Drain anything sent before cancellation was observed
+	for range ch {
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before+1, "Goroutine leak detected")
+}
+
+func TestToChannel_RoundTrip(t *testing.T) {
+	ch := itertools.ToIter([]string{"a", "b"}).ToChannel(context.Background(), 0)
+	result := itertools.FromChannel(ch).ToUpper().Collect()
+
+	assert.Equal(t, []string{"A", "B"}, result)
+}
+
+func TestBuffer(t *testing.T) {
+	result := itertools.Range(0, 100).Buffer(10).Filter(func(x int) bool { return x%10 == 0 }).Collect()
+
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, result)
+}
+
+func TestBuffer_Prefetches(t *testing.T) {
+	var produced atomic.Int32
+	iter := itertools.FromFunc(func() (int, bool) {
+		n := produced.Add(1)
+		return int(n), n <= 100
+	}).Buffer(5)
+
+	assert.True(t, iter.Next())
+	time.Sleep(20 * time.Millisecond)
+
+	// The producer ran ahead of the consumer, bounded by the buffer size
+	assert.Greater(t, produced.Load(), int32(2))
+	assert.LessOrEqual(t, produced.Load(), int32(8))
+	assert.NoError(t, iter.Close())
+}
+
+func TestBuffer_EarlyTermination(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	result := itertools.Range(0, 1000000).Buffer(16).Take(3).Collect()
+	assert.Equal(t, []int{0, 1, 2}, result)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before+1, "Goroutine leak detected")
+}
+
+func TestBuffer_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken}).Buffer(4)
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"a", "b"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestBuffer_EarlyStopIdleInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Each input holds one element and then stays idle
+	idle := func() chan int {
+		ch := make(chan int, 1)
+		ch <- 1
+		t.Cleanup(func() { close(ch) })
+		return ch
+	}
+
+	tests := map[string]*itertools.Iterator[int]{
+		"Buffer":            itertools.FromChannel(idle()).Buffer(4),
+		"BufferWithContext": itertools.FromChannelWithContext(ctx, idle()).BufferWithContext(ctx, 4),
+	}
+	for name, iter := range tests {
+		returned := returnsWithin(time.Second, func() {
+			for v := range iter.Seq() {
+				assert.Equal(t, 1, v)
+				break
+			}
+		})
+		assert.True(t, returned, "%s did not return while its input was idle", name)
+	}
+}
+
+func TestBufferWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter := itertools.Generate(func() int { return 1 }).BufferWithContext(ctx, 4)
+	iter.Collect()
+
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestMerge(t *testing.T) {
+	its := []*itertools.Iterator[int]{
+		itertools.Range(0, 100),
+		itertools.Range(100, 200),
+		itertools.Range(200, 300),
+	}
+	result := itertools.Merge(context.Background(), its...).Collect()
+
+	slices.Sort(result)
+	assert.Equal(t, itertools.Range(0, 300).Collect(), result)
+}
+
+func TestMerge_PreservesPerInputOrder(t *testing.T) {
+	result := itertools.Merge(context.Background(),
+		itertools.ToIter([]string{"a1", "a2", "a3"}),
+		itertools.ToIter([]string{"b1", "b2", "b3"}),
+	).Collect()
+
+	var as, bs []string
+	for _, v := range result {
+		if v[0] == 'a' {
+			as = append(as, v)
+		} else {
+			bs = append(bs, v)
+		}
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3"}, as)
+	assert.Equal(t, []string{"b1", "b2", "b3"}, bs)
+}
+
+func TestMerge_SlowInputDoesNotBlock(t *testing.T) {
+	slow := make(chan int)
+	// Merge waits for every input before returning
+	time.AfterFunc(50*time.Millisecond, func() { close(slow) })
+	fast := itertools.Range(0, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result := itertools.Merge(ctx, itertools.FromChannel(slow), fast).Take(3).Collect()
+
+	assert.Equal(t, []int{0, 1, 2}, result)
+}
+
+func TestMerge_EarlyTermination(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	result := itertools.Merge(context.Background(),
+		itertools.Range(0, 1000000),
+		itertools.Range(0, 1000000),
+	).Take(10).Collect()
+	assert.Equal(t, 10, len(result))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before+1, "Goroutine leak detected")
+}
+
+func TestMerge_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.Merge(context.Background(),
+		itertools.FromReader(&errReader{data: "a\n", err: errBroken}),
+		itertools.Generate(func() string { return "x" }),
+	)
+	iter.Collect()
+
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestMerge_ErrSingleInput(t *testing.T) {
+	errBroken := errors.New("broken")
+	for range 200 {
+		iter := itertools.Merge(context.Background(),
+			itertools.FromReader(&errReader{data: "a\n", err: errBroken}))
+
+		assert.Equal(t, []string{"a"}, iter.Collect())
+		assert.ErrorIs(t, iter.Err(), errBroken)
+	}
+}
+
+func TestMerge_NoReadAfterClose(t *testing.T) {
+	for range 20 {
+		rc := &slowReader{}
+		first := itertools.Merge(context.Background(), itertools.FromReader(rc).OnClose(rc.Close)).First()
+
+		assert.Equal(t, "line", first)
+		assert.False(t, rc.ReadAfterClose())
+	}
+}
+
+func TestMerge_Context(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	never := make(chan int)
+	iter := itertools.Merge(ctx, itertools.FromChannelWithContext(ctx, never))
+	iter.Collect()
+
+	assert.ErrorIs(t, iter.Err(), context.DeadlineExceeded)
+}
+
+func TestMergeRoundRobin(t *testing.T) {
+	result := itertools.MergeRoundRobin(context.Background(),
+		itertools.ToIter([]int{1, 4, 7}),
+		itertools.ToIter([]int{2, 5}),
+		itertools.ToIter([]int{3}),
+	).Collect()
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7}, result)
+}
+
+func TestMergeRoundRobin_EarlyTermination(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	result := itertools.MergeRoundRobin(context.Background(),
+		itertools.Repeat("a", 1000000),
+		itertools.Repeat("b", 1000000),
+	).Take(4).Collect()
+	assert.Equal(t, []string{"a", "b", "a", "b"}, result)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before+1, "Goroutine leak detected")
+}
+
+func TestMergeRoundRobin_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	iter := itertools.MergeRoundRobin(context.Background(),
+		itertools.Repeat("x", 10),
+		itertools.FromReader(&errReader{data: "a\n", err: errBroken}),
+	)
+	result := iter.Collect()
+
+	assert.Equal(t, []string{"x", "a", "x"}, result)
+	assert.ErrorIs(t, iter.Err(), errBroken)
+}
+
+func TestFanIn_EarlyTermination(t *testing.T) {
+	busy := make(chan int)
+	idle := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			busy <- i
+		}
+	}()
+
+	done := make(chan []int)
+	go func() {
+		done <- itertools.FanIn(context.Background(), busy, idle).Take(3).Collect()
+	}()
+
+	select {
+	case result := <-done:
+		assert.Equal(t, []int{0, 1, 2}, result)
+	case <-time.After(time.Second):
+		t.Fatal("FanIn waited on an idle channel after the consumer stopped")
+	}
+}
+
+func TestMergeRoundRobin_EarlyStopWaitsForIdleInput(t *testing.T) {
+	idle := make(chan int)
+	var ended atomic.Bool
+	time.AfterFunc(50*time.Millisecond, func() {
+		ended.Store(true)
+		close(idle)
+	})
+
+	merged := itertools.MergeRoundRobin(context.Background(), itertools.Range(0, 10), itertools.FromChannel(idle))
+	for v := range merged.Seq() {
+		assert.Equal(t, 0, v)
+		break
+	}
+
+	assert.True(t, ended.Load(), "MergeRoundRobin returned while an input was still reading")
+}
+
+func TestMergeRoundRobin_NoReadAfterClose(t *testing.T) {
+	for range 20 {
+		rc := &slowReader{}
+		first := itertools.MergeRoundRobin(context.Background(), itertools.FromReader(rc).OnClose(rc.Close)).First()
+
+		assert.Equal(t, "line", first)
+		assert.False(t, rc.ReadAfterClose())
+	}
+}
+
+func TestFanIn(t *testing.T) {
+	ch1 := make(chan int)
+	ch2 := make(chan int)
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch1 <- i
+		}
+		close(ch1)
+	}()
+	go func() {
+		for i := 5; i < 10; i++ {
+			ch2 <- i
+		}
+		close(ch2)
+	}()
+
+	result := itertools.FanIn(context.Background(), ch1, ch2).Collect()
+
+	slices.Sort(result)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, result)
+}
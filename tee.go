@@ -0,0 +1,268 @@
+package itertools
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ErrTeeOverflow is reported by Err on a Tee copy that fell further behind
+// the fastest copy than the shared buffer allows, when WithTeeOverflowError
+// is used.
+var ErrTeeOverflow = errors.New("itertools: tee consumer fell too far behind")
+
+// TeeOption configures Tee.
+type TeeOption func(*teeConfig)
+
+type teeConfig struct {
+	size          int
+	overflowError bool
+}
+
+// WithTeeBuffer sets the maximum number of elements buffered between the
+// fastest and the slowest Tee copy. The default is 1024.
+func WithTeeBuffer(size int) TeeOption {
+	return func(c *teeConfig) {
+		c.size = max(size, 1)
+	}
+}
+
+// WithTeeOverflowError makes Tee drop a copy that falls too far behind
+// instead of blocking the faster copies. The dropped copy stops and its Err
+// returns ErrTeeOverflow.
+func WithTeeOverflowError() TeeOption {
+	return func(c *teeConfig) {
+		c.overflowError = true
+	}
+}
+
+// Tee splits the iterator into n iterators that each yield every element,
+// reading the source only once. Elements are kept in a shared buffer until
+// every copy has consumed them, so memory is bounded by the distance
+// between the fastest and the slowest copy.
+//
+// When the buffer is full, the fastest copy blocks until the slowest one
+// catches up, so copies should be consumed concurrently unless the buffer
+// can hold the whole input. With WithTeeOverflowError, the slowest copy is
+// dropped instead. A copy that stops early, or is closed, no longer holds
+// back the others. The source is closed once every copy is closed,
+// including copies that were consumed to the end.
+//
+// Each copy can be iterated only once and may be consumed from its own
+// goroutine.
+//
+// Example:
+//
+//	rows := itertools.FromCSV(csv.NewReader(file))
+//	copies := rows.Tee(2, itertools.WithTeeBuffer(256))
+//	var wg sync.WaitGroup
+//	var count, total int
+//	wg.Add(2)
+//	go func() { defer wg.Done(); count = copies[0].Count() }()
+//	go func() { defer wg.Done(); total = itertools.Sum(copies[1], amount, 0) }()
+//	wg.Wait()
+func (it *Iterator[V]) Tee(n int, opts ...TeeOption) []*Iterator[V] {
+	cfg := teeConfig{size: 1024}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	b := &teeBuffer[V]{
+		src:    it,
+		size:   cfg.size,
+		drop:   cfg.overflowError,
+		pos:    make([]int, n),
+		active: make([]bool, n),
+		closed: make([]bool, n),
+		errs:   make([]error, n),
+	}
+	b.cond = sync.NewCond(&b.mu)
+
+	copies := make([]*Iterator[V], n)
+	for i := range copies {
+		b.active[i] = true
+		copies[i] = &Iterator[V]{
+			seq: func(yield func(V) bool) {
+				defer b.release(i)
+				for {
+					v, ok := b.next(i)
+					if !ok || !yield(v) {
+						return
+					}
+				}
+			},
+			err:    func() error { return b.err(i) },
+			closer: func() error { return b.close(i) },
+		}
+	}
+	return copies
+}
+
+// teeBuffer is the state shared by the copies returned from Tee.
+type teeBuffer[V any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	src  *Iterator[V]
+	pull func() (V, bool)
+	stop func()
+	done bool
+	// reading is true while a copy reads the source without holding mu
+	reading bool
+
+	// buf holds the elements from absolute index base onwards
+	buf  []V
+	base int
+	size int
+	drop bool
+
+	// pos is the absolute index of the next element for each copy
+	pos    []int
+	active []bool
+	// closed records the copies that have been closed, which may be fewer
+	// than the inactive ones: a copy that finished iterating is inactive
+	// but still open
+	closed []bool
+	errs   []error
+}
+
+// next returns the next element for copy i, reading from the source when
+// copy i is the fastest one.
+func (b *teeBuffer[V]) next(i int) (V, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var zero V
+	for {
+		if !b.active[i] {
+			return zero, false
+		}
+		if p := b.pos[i]; p < b.base+len(b.buf) {
+			v := b.buf[p-b.base]
+			b.pos[i]++
+			b.trim()
+			return v, true
+		}
+		if b.done {
+			return zero, false
+		}
+		if b.reading {
+			// Another copy is reading the next element
+			b.cond.Wait()
+			continue
+		}
+		if len(b.buf) >= b.size {
+			if b.drop {
+				b.dropSlowest()
+			} else {
+				b.cond.Wait()
+			}
+			continue
+		}
+		if b.pull == nil {
+			b.pull, b.stop = iter.Pull(b.src.seq)
+		}
+
+		// Read without holding the lock, so that the other copies can keep
+		// consuming buffered elements during a slow read
+		b.reading = true
+		b.mu.Unlock()
+		v, ok := b.pull()
+		b.mu.Lock()
+		b.reading = false
+		b.cond.Broadcast()
+
+		if b.done {
+			// Every copy was released during the read
+			b.stop()
+			return zero, false
+		}
+		if !ok {
+			b.done = true
+			b.stop()
+			return zero, false
+		}
+		b.buf = append(b.buf, v)
+	}
+}
+
+// trim discards the buffered elements that every active copy has consumed.
+func (b *teeBuffer[V]) trim() {
+	lowest := b.base + len(b.buf)
+	for j, p := range b.pos {
+		if b.active[j] && p < lowest {
+			lowest = p
+		}
+	}
+	if n := lowest - b.base; n > 0 {
+		clear(b.buf[:n])
+		b.buf = b.buf[n:]
+		b.base = lowest
+		b.cond.Broadcast()
+	}
+}
+
+// dropSlowest deactivates the copies that hold back a full buffer.
+func (b *teeBuffer[V]) dropSlowest() {
+	for j, p := range b.pos {
+		if b.active[j] && p == b.base {
+			b.active[j] = false
+			b.errs[j] = ErrTeeOverflow
+		}
+	}
+	b.trim()
+}
+
+// release deactivates copy i and stops reading the source once no copy is
+// left.
+func (b *teeBuffer[V]) release(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.active[i] = false
+	b.trim()
+	for _, active := range b.active {
+		if active {
+			return
+		}
+	}
+	if b.stop != nil && !b.done {
+		b.done = true
+		// A copy reading the source stops it once the read returns
+		if !b.reading {
+			b.stop()
+		}
+	}
+	b.cond.Broadcast()
+}
+
+// close releases copy i and closes the source once every copy is closed.
+// It is called at most once per copy.
+func (b *teeBuffer[V]) close(i int) error {
+	b.release(i)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed[i] = true
+	for _, closed := range b.closed {
+		if !closed {
+			return nil
+		}
+	}
+	return b.src.Close()
+}
+
+// err reports the error of copy i: ErrTeeOverflow if it was dropped, or the
+// source's error once the source is exhausted.
+func (b *teeBuffer[V]) err(i int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.errs[i] != nil {
+		return b.errs[i]
+	}
+	if b.done {
+		return b.src.Err()
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package itertools_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator_Tee(t *testing.T) {
+	copies := itertools.Range(0, 5).Tee(2)
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, copies[0].Collect())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, copies[1].Collect())
+}
+
+func TestIterator_Tee_ReadsSourceOnce(t *testing.T) {
+	reads := 0
+	source := itertools.FromFunc(func() (int, bool) {
+		reads++
+		return reads, reads <= 100
+	})
+	copies := source.Tee(3, itertools.WithTeeBuffer(8))
+
+	var wg sync.WaitGroup
+	var count, sum int
+	var sample []int
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		count = copies[0].Count()
+	}()
+	go func() {
+		defer wg.Done()
+		sum = itertools.Sum(copies[1], func(v int) int { return v }, 0)
+	}()
+	go func() {
+		defer wg.Done()
+		sample = copies[2].StepBy(25).Collect()
+	}()
+	wg.Wait()
+
+	assert.Equal(t, 100, count)
+	assert.Equal(t, 5050, sum)
+	assert.Equal(t, []int{1, 26, 51, 76}, sample)
+	assert.Equal(t, 101, reads)
+}
+
+func TestIterator_Tee_EarlyStopReleasesCopy(t *testing.T) {
+	copies := itertools.Range(0, 100).Tee(2, itertools.WithTeeBuffer(4))
+
+	// The first copy stops early, so it no longer holds back the second
+	assert.Equal(t, []int{0, 1}, copies[0].Take(2).Collect())
+	assert.Equal(t, 100, copies[1].Count())
+}
+
+func TestIterator_Tee_OverflowError(t *testing.T) {
+	copies := itertools.Range(0, 10).Tee(2, itertools.WithTeeBuffer(4), itertools.WithTeeOverflowError())
+
+	assert.Equal(t, itertools.Range(0, 10).Collect(), copies[0].Collect())
+	assert.NoError(t, copies[0].Err())
+
+	assert.Empty(t, copies[1].Collect())
+	assert.ErrorIs(t, copies[1].Err(), itertools.ErrTeeOverflow)
+}
+
+func TestIterator_Tee_Err(t *testing.T) {
+	errBroken := errors.New("broken")
+	copies := itertools.FromReader(&errReader{data: "a\nb\n", err: errBroken}).Tee(2)
+
+	assert.Equal(t, []string{"a", "b"}, copies[0].Collect())
+	assert.Equal(t, []string{"a", "b"}, copies[1].Collect())
+	assert.ErrorIs(t, copies[0].Err(), errBroken)
+	assert.ErrorIs(t, copies[1].Err(), errBroken)
+}
+
+func TestIterator_Tee_Close(t *testing.T) {
+	calls := 0
+	copies := itertools.Range(0, 10).OnClose(func() error {
+		calls++
+		return nil
+	}).Tee(2)
+
+	assert.NoError(t, copies[0].Close())
+	assert.Equal(t, 0, calls)
+	assert.NoError(t, copies[1].Close())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterator_Tee_CloseAfterFinished(t *testing.T) {
+	calls := 0
+	copies := itertools.Range(0, 3).OnClose(func() error {
+		calls++
+		return nil
+	}).Tee(2)
+
+	assert.Equal(t, []int{0, 1, 2}, copies[0].Collect())
+	assert.NoError(t, copies[1].Close())
+	assert.Equal(t, 0, calls, "source closed while a copy was still open")
+	assert.NoError(t, copies[0].Close())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterator_Tee_SlowReadDoesNotBlockBuffered(t *testing.T) {
+	src := make(chan int, 1)
+	src <- 1
+	copies := itertools.FromChannel(src).Tee(2)
+	defer close(src)
+
+	// Copy 0 reads 1, then blocks reading the source for the next element
+	assert.True(t, copies[0].Next())
+	assert.Equal(t, 1, copies[0].Current())
+	go copies[0].Next()
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan int)
+	go func() {
+		copies[1].Next()
+		done <- copies[1].Current()
+	}()
+	select {
+	case v := <-done:
+		assert.Equal(t, 1, v)
+	case <-time.After(time.Second):
+		t.Fatal("buffered element was blocked by a read in another copy")
+	}
+}